@@ -0,0 +1,37 @@
+package jin
+
+import (
+	"encoding/base64"
+	"strings"
+)
+
+// decodeBase64 decodes standard or URL-safe base64 with or without padding.
+// Padding is optional, but if there is, it must be exactly what the encoding adds, like "aGk=" not "aGk==".
+func decodeBase64(val string) ([]byte, error) {
+	raw := strings.TrimRight(val, "=")
+	enc := base64.RawStdEncoding
+	if strings.ContainsAny(raw, "-_") {
+		enc = base64.RawURLEncoding
+	}
+	data, err := enc.DecodeString(raw)
+	if err != nil {
+		return nil, base64Error(val)
+	}
+	if padding := len(val) - len(raw); padding != 0 && padding != (4-len(raw)%4)%4 {
+		return nil, base64Error(val)
+	}
+	return data, nil
+}
+
+// encodeBase64 returns data as a JSON string encoded with enc, nil enc is base64.StdEncoding.
+func encodeBase64(data []byte, enc *base64.Encoding) []byte {
+	if enc == nil {
+		enc = base64.StdEncoding
+	}
+	buf := make([]byte, enc.EncodedLen(len(data))+2)
+	// 34 = "
+	buf[0] = 34
+	enc.Encode(buf[1:], data)
+	buf[len(buf)-1] = 34
+	return buf
+}
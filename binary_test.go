@@ -0,0 +1,72 @@
+package jin
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDecodeBase64(t *testing.T) {
+	tests := []struct {
+		val  string
+		data string
+		ok   bool
+	}{
+		{"", "", true},
+		{"aGk=", "hi", true},
+		{"aGk", "hi", true},
+		{"aGVsbG8=", "hello", true},
+		{"aGVsbA==", "hell", true},
+		{"aGVsbA", "hell", true},
+		{"aGVs", "hel", true},
+		{"-_8=", "\xfb\xff", true},
+		{"-_8", "\xfb\xff", true},
+		{"+/8=", "\xfb\xff", true},
+		// over padded
+		{"aGk==", "", false},
+		{"aGk===", "", false},
+		{"aGVsbA===", "", false},
+		{"aGVs=", "", false},
+		{"aGVs==", "", false},
+		{"=", "", false},
+		{"==", "", false},
+		// under padded
+		{"aGVsbA=", "", false},
+		// padding in the middle
+		{"aG=k", "", false},
+		{"aGk=aGk=", "", false},
+		// bad length and characters
+		{"a", "", false},
+		{"aGVsb", "", false},
+		{"aG!k", "", false},
+		{"+-8=", "", false},
+	}
+	for _, test := range tests {
+		data, err := decodeBase64(test.val)
+		if !test.ok {
+			if !errors.Is(err, ErrBase64) {
+				t.Errorf("%q: got %q %v, want ErrBase64", test.val, data, err)
+			}
+			continue
+		}
+		if err != nil || string(data) != test.data {
+			t.Errorf("%q: got %q %v, want %q", test.val, data, err, test.data)
+		}
+	}
+}
+
+func TestGetBytesOverPadded(t *testing.T) {
+	json := []byte(`{"a":"aGk==","b":"aGk="}`)
+	if _, err := GetBytes(json, "a"); !errors.Is(err, ErrBase64) {
+		t.Errorf("got %v, want ErrBase64", err)
+	}
+	if data, err := GetBytes(json, "b"); err != nil || string(data) != "hi" {
+		t.Errorf("got %q %v, want hi", data, err)
+	}
+	pars, err := Parse(json)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pars.GetBytes("a"); !errors.Is(err, ErrBase64) {
+		t.Errorf("got %v, want ErrBase64", err)
+	}
+}
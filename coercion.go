@@ -0,0 +1,313 @@
+package jin
+
+import (
+	"math"
+	"strconv"
+)
+
+// FloatToInt is the policy of integer getters for numbers that has fraction or exponent parts.
+type FloatToInt int
+
+const (
+	// FloatReject rejects numbers like 42.0 or 4.2e1, like GetInt() does.
+	FloatReject FloatToInt = iota
+	// FloatExact accepts numbers that has no fractional value like 42.0 or 4.2e1, and rejects 42.5.
+	FloatExact
+	// FloatTruncate truncates fractional values, 42.9 is 42 and -42.9 is -42.
+	FloatTruncate
+)
+
+// Coercion is an opt-in type conversion policy of typed getters,
+// for loosely typed payloads that writes numbers as strings or booleans as numbers.
+// Zero value is the strict mode, values must have the type that getter returns, so "42" is not an int.
+// Strict mode is stricter than the default getters without a policy, they accept
+// quoted scalars like "42" for GetInt() or "true" for GetBool(), but reject 42.0 and 1.
+// A policy can be used per call, like LooseCoercion.GetInt(json, "id"),
+// or per Parser with SetCoercion().
+type Coercion struct {
+	// StringToNumber accepts numbers in strings, like "42" or "4.2".
+	StringToNumber bool
+	// FloatToInt is the policy of integer getters for numbers like 42.0 or 42.5.
+	FloatToInt FloatToInt
+	// NumberToBool accepts 1 and 0 as true and false.
+	NumberToBool bool
+	// StringToBool accepts booleans in strings, like "true", "FALSE", "1" or "0".
+	StringToBool bool
+}
+
+var (
+	// StrictCoercion converts nothing, "42" and 42.0 are not ints, "true" and 1 are not bools.
+	// Unlike the default getters it also rejects quoted scalars, so GetInt() of "42" is 42 but StrictCoercion.GetInt() fails.
+	StrictCoercion = Coercion{}
+	// LooseCoercion converts everything it can, "42" and 42.9 are 42, "true" and 1 are true.
+	LooseCoercion = Coercion{StringToNumber: true, FloatToInt: FloatTruncate, NumberToBool: true, StringToBool: true}
+)
+
+// GetInt is a variation of GetInt() func, values are converted with the policy.
+func (c Coercion) GetInt(json []byte, path ...string) (int, error) {
+	raw, err := rawValue(json, path)
+	if err != nil {
+		return -1, err
+	}
+	val, quoted := scalar(raw)
+	num, err := c.toInt(val, quoted, strconv.IntSize, "int")
+	if err != nil {
+		return -1, err
+	}
+	return int(num), nil
+}
+
+// GetInt64 is a variation of GetInt64() func, values are converted with the policy.
+func (c Coercion) GetInt64(json []byte, path ...string) (int64, error) {
+	raw, err := rawValue(json, path)
+	if err != nil {
+		return -1, err
+	}
+	val, quoted := scalar(raw)
+	return c.toInt(val, quoted, 64, "int64")
+}
+
+// GetUint64 is a variation of GetUint64() func, values are converted with the policy.
+func (c Coercion) GetUint64(json []byte, path ...string) (uint64, error) {
+	raw, err := rawValue(json, path)
+	if err != nil {
+		return 0, err
+	}
+	val, quoted := scalar(raw)
+	return c.toUint(val, quoted)
+}
+
+// GetFloat is a variation of GetFloat() func, values are converted with the policy.
+func (c Coercion) GetFloat(json []byte, path ...string) (float64, error) {
+	raw, err := rawValue(json, path)
+	if err != nil {
+		return -1, err
+	}
+	val, quoted := scalar(raw)
+	return c.toFloat(val, quoted)
+}
+
+// GetBool is a variation of GetBool() func, values are converted with the policy.
+func (c Coercion) GetBool(json []byte, path ...string) (bool, error) {
+	raw, err := rawValue(json, path)
+	if err != nil {
+		return false, err
+	}
+	val, quoted := scalar(raw)
+	return c.toBool(val, quoted)
+}
+
+// GetIntArray is a variation of GetIntArray() func, elements are converted with the policy.
+func (c Coercion) GetIntArray(json []byte, path ...string) ([]int, error) {
+	raw, err := rawValue(json, path)
+	if err != nil {
+		return nil, err
+	}
+	return c.intArray(raw)
+}
+
+// GetInt64Array is a variation of GetInt64Array() func, elements are converted with the policy.
+func (c Coercion) GetInt64Array(json []byte, path ...string) ([]int64, error) {
+	raw, err := rawValue(json, path)
+	if err != nil {
+		return nil, err
+	}
+	return c.int64Array(raw)
+}
+
+// GetUint64Array is a variation of GetUint64Array() func, elements are converted with the policy.
+func (c Coercion) GetUint64Array(json []byte, path ...string) ([]uint64, error) {
+	raw, err := rawValue(json, path)
+	if err != nil {
+		return nil, err
+	}
+	return c.uint64Array(raw)
+}
+
+// GetFloatArray is a variation of GetFloatArray() func, elements are converted with the policy.
+func (c Coercion) GetFloatArray(json []byte, path ...string) ([]float64, error) {
+	raw, err := rawValue(json, path)
+	if err != nil {
+		return nil, err
+	}
+	return c.floatArray(raw)
+}
+
+// GetBoolArray is a variation of GetBoolArray() func, elements are converted with the policy.
+func (c Coercion) GetBoolArray(json []byte, path ...string) ([]bool, error) {
+	raw, err := rawValue(json, path)
+	if err != nil {
+		return nil, err
+	}
+	return c.boolArray(raw)
+}
+
+func (c Coercion) intArray(raw []byte) ([]int, error) {
+	return coerceArray(raw, intArrayParseError, func(val string, quoted bool) (int, error) {
+		num, err := c.toInt(val, quoted, strconv.IntSize, "int")
+		return int(num), err
+	})
+}
+
+func (c Coercion) int64Array(raw []byte) ([]int64, error) {
+	return coerceArray(raw, intArrayParseError, func(val string, quoted bool) (int64, error) {
+		return c.toInt(val, quoted, 64, "int64")
+	})
+}
+
+func (c Coercion) uint64Array(raw []byte) ([]uint64, error) {
+	return coerceArray(raw, intArrayParseError, c.toUint)
+}
+
+func (c Coercion) floatArray(raw []byte) ([]float64, error) {
+	return coerceArray(raw, floatArrayParseError, c.toFloat)
+}
+
+func (c Coercion) boolArray(raw []byte) ([]bool, error) {
+	return coerceArray(raw, boolArrayParseError, c.toBool)
+}
+
+// toInt converts val to an integer of bitSize, quoted reports whether val is a string in JSON.
+func (c Coercion) toInt(val string, quoted bool, bitSize int, typeName string) (int64, error) {
+	if quoted && !c.StringToNumber {
+		return 0, intParseError(val)
+	}
+	num, err := strconv.ParseInt(val, 10, bitSize)
+	if err == nil {
+		return num, nil
+	}
+	if c.FloatToInt == FloatReject || len(val) == 0 || !isNumber(val) {
+		return 0, rangeError(err, val, typeName, intParseError(val))
+	}
+	f, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return 0, rangeError(err, val, typeName, intParseError(val))
+	}
+	if c.FloatToInt == FloatExact && f != math.Trunc(f) {
+		return 0, intParseError(val)
+	}
+	f = math.Trunc(f)
+	limit := math.Ldexp(1, bitSize-1)
+	if f < -limit || f >= limit {
+		return 0, numberOverflowError(val, typeName)
+	}
+	return int64(f), nil
+}
+
+// toUint converts val to an uint64, negative values return an error with code ErrNumberOverflow like GetUint64() does.
+func (c Coercion) toUint(val string, quoted bool) (uint64, error) {
+	if quoted && !c.StringToNumber {
+		return 0, intParseError(val)
+	}
+	num, err := Number(val).Uint64()
+	if err == nil || c.FloatToInt == FloatReject || len(val) == 0 || !isNumber(val) {
+		return num, err
+	}
+	f, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return 0, rangeError(err, val, "uint64", intParseError(val))
+	}
+	if c.FloatToInt == FloatExact && f != math.Trunc(f) {
+		return 0, intParseError(val)
+	}
+	f = math.Trunc(f)
+	if f < 0 || f >= math.Ldexp(1, 64) {
+		return 0, numberOverflowError(val, "uint64")
+	}
+	return uint64(f), nil
+}
+
+// toFloat converts val to a float, only JSON numbers are accepted so "NaN" or "0x10" are not floats.
+func (c Coercion) toFloat(val string, quoted bool) (float64, error) {
+	if (quoted && !c.StringToNumber) || len(val) == 0 || !isNumber(val) {
+		return 0, floatParseError(val)
+	}
+	num, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return 0, rangeError(err, val, "float64", floatParseError(val))
+	}
+	return num, nil
+}
+
+func (c Coercion) toBool(val string, quoted bool) (bool, error) {
+	if quoted {
+		if c.StringToBool {
+			if b, err := strconv.ParseBool(val); err == nil {
+				return b, nil
+			}
+		}
+		return false, boolParseError(val)
+	}
+	switch {
+	case val == "true":
+		return true, nil
+	case val == "false":
+		return false, nil
+	case c.NumberToBool && val == "1":
+		return true, nil
+	case c.NumberToBool && val == "0":
+		return false, nil
+	}
+	return false, boolParseError(val)
+}
+
+// coerceArray converts the elements of the array raw with convert.
+// arrayError is the error for values that are not an array.
+func coerceArray[T any](raw []byte, arrayError func(string) *Error, convert func(val string, quoted bool) (T, error)) ([]T, error) {
+	start := skipSpace(raw, 0)
+	// 91 = [
+	if start == len(raw) || raw[start] != 91 {
+		return nil, arrayError(string(raw))
+	}
+	arr := make([]T, 0, 16)
+	var convErr error
+	err := eachChild(raw, start, func(label string, start, end int) bool {
+		val, quoted := scalar(raw[start:end])
+		var elem T
+		elem, convErr = convert(val, quoted)
+		if convErr != nil {
+			return false
+		}
+		arr = append(arr, elem)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if convErr != nil {
+		return nil, convErr
+	}
+	return arr, nil
+}
+
+// rawValue returns the value that path has pointed, with quotation marks of strings.
+func rawValue(json []byte, path []string) ([]byte, error) {
+	if len(path) == 0 {
+		return json, nil
+	}
+	_, start, end, err := core(json, false, path...)
+	if err != nil {
+		return nil, err
+	}
+	// core() returns strings without quotation marks.
+	if start > 0 && json[start-1] == 34 && end < len(json) && json[end] == 34 {
+		return json[start-1 : end+1], nil
+	}
+	return json[start:end], nil
+}
+
+// scalar returns the text of a raw value and reports whether it is a string,
+// escape sequences of strings are decoded.
+func scalar(raw []byte) (string, bool) {
+	start := skipSpace(raw, 0)
+	end := len(raw)
+	for end > start && space(raw[end-1]) {
+		end--
+	}
+	raw = raw[start:end]
+	// 34 = "
+	if len(raw) > 1 && raw[0] == 34 && raw[len(raw)-1] == 34 {
+		return string(unescape(raw[1 : len(raw)-1])), true
+	}
+	return string(raw), false
+}
@@ -0,0 +1,108 @@
+package jin
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCoercionUint64(t *testing.T) {
+	json := []byte(`{"n":42,"s":"42","f":42.0,"h":42.9,"neg":-1,"big":18446744073709551616,"arr":["1",2.0,3]}`)
+	pars, err := Parse(json)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exact := Coercion{StringToNumber: true, FloatToInt: FloatExact}
+	tests := []struct {
+		key    string
+		policy Coercion
+		want   uint64
+		err    error
+	}{
+		{"n", StrictCoercion, 42, nil},
+		{"s", StrictCoercion, 0, ErrIntParse},
+		{"f", StrictCoercion, 0, ErrIntParse},
+		{"s", LooseCoercion, 42, nil},
+		{"f", LooseCoercion, 42, nil},
+		{"h", LooseCoercion, 42, nil},
+		{"f", exact, 42, nil},
+		{"h", exact, 0, ErrIntParse},
+		{"neg", LooseCoercion, 0, ErrNumberOverflow},
+		{"neg", StrictCoercion, 0, ErrNumberOverflow},
+		{"big", LooseCoercion, 0, ErrNumberOverflow},
+		{"missing", LooseCoercion, 0, ErrKeyNotFound},
+	}
+	for _, test := range tests {
+		got, err := test.policy.GetUint64(json, test.key)
+		policy := test.policy
+		pars.SetCoercion(&policy)
+		parsGot, parsErr := pars.GetUint64(test.key)
+		if test.err != nil {
+			if !errors.Is(err, test.err) || !errors.Is(parsErr, test.err) {
+				t.Errorf("%v %+v: got %v and %v, want %v", test.key, test.policy, err, parsErr, test.err)
+			}
+			continue
+		}
+		if err != nil || parsErr != nil || got != test.want || parsGot != test.want {
+			t.Errorf("%v %+v: got %v %v and %v %v, want %v", test.key, test.policy, got, err, parsGot, parsErr, test.want)
+		}
+	}
+
+	if _, err := StrictCoercion.GetUint64Array(json, "arr"); !errors.Is(err, ErrIntParse) {
+		t.Errorf("strict array returned %v, want ErrIntParse", err)
+	}
+	want := []uint64{1, 2, 3}
+	arr, err := LooseCoercion.GetUint64Array(json, "arr")
+	if err != nil || !reflect.DeepEqual(arr, want) {
+		t.Errorf("loose array returned %v %v, want %v", arr, err, want)
+	}
+	pars.SetCoercion(&LooseCoercion)
+	arr, err = pars.GetUint64Array("arr")
+	if err != nil || !reflect.DeepEqual(arr, want) {
+		t.Errorf("parser loose array returned %v %v, want %v", arr, err, want)
+	}
+	pars.SetCoercion(&StrictCoercion)
+	if _, err := pars.GetUint64Array("arr"); !errors.Is(err, ErrIntParse) {
+		t.Errorf("parser strict array returned %v, want ErrIntParse", err)
+	}
+}
+
+func TestCoercionDefaultIsNotStrict(t *testing.T) {
+	json := []byte(`{"s":"42","f":42.0,"b":"true","n":1}`)
+	pars, err := Parse(json)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// default getters accept quoted scalars.
+	if got, err := GetInt(json, "s"); err != nil || got != 42 {
+		t.Errorf("default GetInt returned %v %v, want 42", got, err)
+	}
+	if got, err := pars.GetInt("s"); err != nil || got != 42 {
+		t.Errorf("default parser GetInt returned %v %v, want 42", got, err)
+	}
+	if got, err := GetBool(json, "b"); err != nil || !got {
+		t.Errorf("default GetBool returned %v %v, want true", got, err)
+	}
+	// but they do not convert between types.
+	if _, err := GetInt(json, "f"); !errors.Is(err, ErrIntParse) {
+		t.Errorf("default GetInt of 42.0 returned %v, want ErrIntParse", err)
+	}
+	if _, err := GetBool(json, "n"); !errors.Is(err, ErrBoolParse) {
+		t.Errorf("default GetBool of 1 returned %v, want ErrBoolParse", err)
+	}
+	// strict mode rejects quoted scalars too.
+	if _, err := StrictCoercion.GetInt(json, "s"); !errors.Is(err, ErrIntParse) {
+		t.Errorf("strict GetInt returned %v, want ErrIntParse", err)
+	}
+	if _, err := StrictCoercion.GetBool(json, "b"); !errors.Is(err, ErrBoolParse) {
+		t.Errorf("strict GetBool returned %v, want ErrBoolParse", err)
+	}
+	pars.SetCoercion(&StrictCoercion)
+	if _, err := pars.GetInt("s"); !errors.Is(err, ErrIntParse) {
+		t.Errorf("strict parser GetInt returned %v, want ErrIntParse", err)
+	}
+	pars.SetCoercion(nil)
+	if got, err := pars.GetInt("s"); err != nil || got != 42 {
+		t.Errorf("restored parser GetInt returned %v %v, want 42", got, err)
+	}
+}
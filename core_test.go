@@ -295,12 +295,12 @@ func TestInterperterIterateArray(t *testing.T) {
 			t.Logf("warning: %v, func: %v, path: %v", errorNullArray.Error(), sticker, path)
 			return []byte(expected), nil, expected, sticker
 		}
-		array := ParseArray(expected)
+		array := parseArray(expected, true)
 		count := 0
 		done := true
 		err := IterateArray(json, func(value []byte) bool {
 			got := formatValue(value)
-			expected := stripQuotes(array[count])
+			expected := array[count]
 			if got != expected {
 				t.Logf("path:%v\n", path)
 				t.Logf("got:%v\n", got)
@@ -335,13 +335,13 @@ func TestInterperterIterateKeyValue(t *testing.T) {
 		}
 		done := true
 		err := IterateKeyValue(json, func(key, value []byte) bool {
-			value2, err := Get(json, append(path, string(key))...)
+			value2, err := GetString(json, append(path, string(key))...)
 			if err != nil {
 				done = false
 				return false
 			}
 			got := formatValue(value)
-			expected := formatValue(value2)
+			expected := formatValue([]byte(value2))
 			if got != expected {
 				t.Logf("path:%v\n", path)
 				t.Logf("got:%v\n", got)
@@ -370,7 +370,7 @@ func TestInterpreterGetKeys(t *testing.T) {
 			t.Logf("error. %v\n", err)
 			return nil, err, expected, sticker
 		}
-		expKeys := ParseArray(expected)
+		expKeys := parseArray(expected, true)
 		if !stringArrayEqual(keys, expKeys) {
 			return []byte("some element"), errors.New("not equal."), "some element", sticker
 		}
@@ -387,7 +387,7 @@ func TestInterpreterGetValues(t *testing.T) {
 			t.Logf("error. %v\n", err)
 			return nil, err, expected, sticker
 		}
-		expValues := ParseArray(expected)
+		expValues := parseArray(expected, true)
 		if !stringArrayEqual(values, expValues) {
 			return []byte("some element"), errors.New("not equal."), "some element", sticker
 		}
@@ -622,3 +622,26 @@ func TestParserDelete(t *testing.T) {
 		return value, nil, expected, sticker
 	})
 }
+
+func TestCoreTruncatedInput(t *testing.T) {
+	tests := []struct {
+		json   string
+		offset int
+	}{
+		{"   ", 3},
+		{"\n\t ", 3},
+		{`{"a":`, 5},
+		{`{"a":   `, 8},
+		{`{"b":1,"a": ` + "\n", 13},
+	}
+	for _, test := range tests {
+		_, err := Get([]byte(test.json), "a")
+		var e *Error
+		if !errors.As(err, &e) || e.Code != ErrBadJSON.Code || e.Offset != test.offset {
+			t.Errorf("%q: got %v, want a bad json error at %v", test.json, err, test.offset)
+		}
+		if v := GetIntOr([]byte(test.json), 7, "a"); v != 7 {
+			t.Errorf("%q: GetIntOr returned %v, want 7", test.json, v)
+		}
+	}
+}
@@ -1,70 +1,298 @@
 package jin
 
 import (
-	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 )
 
-func nullPathError() error {
-	return errors.New("error: path cannot be null error_code:00 ")
+// Error is the error type that all functions of this package returns.
+// Errors can be checked with errors.Is() against sentinel errors like ErrKeyNotFound,
+// or can be inspected with errors.As() for more detail.
+//
+//	var e *jin.Error
+//	if errors.As(err, &e) {
+//		fmt.Println(e.Code, e.Offset, e.Segment)
+//	}
+type Error struct {
+	// Code is the error code, same with the 'error_code' of error message.
+	Code int
+	// Offset is the byte offset of JSON that error occurred, -1 if it is unknown.
+	Offset int
+	// Segment is the path segment that failed, empty if error is not related with a path.
+	Segment string
+	// Line and Column are the 1-based position of Offset, zero if they are unknown.
+	// Column counts runes, not bytes.
+	Line   int
+	Column int
+	// Expected is the token that expected at Offset, for bad JSON errors.
+	Expected string
+	// Excerpt is a short part of the line that error occurred,
+	// with a second line that has a caret under the Offset.
+	//
+	//	{"a":tru,,}
+	//	        ^
+	Excerpt string
+	msg     string
 }
-func nullNewValueError() error {
-	return errors.New("error: new value cannot be null error_code:01 ")
+
+// Sentinel errors, only error codes are compared with errors.Is()
+var (
+	ErrNullPath         = &Error{Code: 0, Offset: -1, msg: "error: path cannot be null"}
+	ErrNullNewValue     = &Error{Code: 1, Offset: -1, msg: "error: new value cannot be null"}
+	ErrEmptyArray       = &Error{Code: 2, Offset: -1, msg: "error: array is empty"}
+	ErrIndexExpected    = &Error{Code: 3, Offset: -1, msg: "error: index expected, got key value"}
+	ErrKeyExpected      = &Error{Code: 4, Offset: -1, msg: "error: key expected, got index"}
+	ErrObjectExpected   = &Error{Code: 5, Offset: -1, msg: "error: last path must be pointed at an object"}
+	ErrArrayExpected    = &Error{Code: 6, Offset: -1, msg: "error: last path must be pointed at an array"}
+	ErrIndexOutOfRange  = &Error{Code: 7, Offset: -1, msg: "error: index out of range"}
+	ErrKeyNotFound      = &Error{Code: 8, Offset: -1, msg: "error: key not found"}
+	ErrBadJSON          = &Error{Code: 9, Offset: -1, msg: "error: bad json format."}
+	ErrBadKey           = &Error{Code: 10, Offset: -1, msg: "error: key value cannot contain quote symbol."}
+	ErrKeyAlreadyExists = &Error{Code: 11, Offset: -1, msg: "error: key already exist"}
+	ErrIntParse         = &Error{Code: 12, Offset: -1, msg: "parse error: value cannot be converted to int."}
+	ErrFloatParse       = &Error{Code: 13, Offset: -1, msg: "parse error: value cannot be converted to float."}
+	ErrBoolParse        = &Error{Code: 14, Offset: -1, msg: "parse error: value cannot be converted to bool."}
+	ErrStringArrayParse = &Error{Code: 15, Offset: -1, msg: "parse error: value cannot be converted to []string."}
+	ErrIntArrayParse    = &Error{Code: 16, Offset: -1, msg: "parse error: value cannot be converted to []int."}
+	ErrFloatArrayParse  = &Error{Code: 17, Offset: -1, msg: "parse error: value cannot be converted to []float."}
+	ErrBoolArrayParse   = &Error{Code: 18, Offset: -1, msg: "parse error: value cannot be converted to []bool."}
+	ErrNullKey          = &Error{Code: 19, Offset: -1, msg: "error: new key cannot be null"}
+	ErrEmpty            = &Error{Code: 20, Offset: -1, msg: "error: Object/Array is empty"}
+	ErrBadPath          = &Error{Code: 21, Offset: -1, msg: "error: bad path expression."}
+	ErrBadPointer       = &Error{Code: 22, Offset: -1, msg: "error: bad json pointer."}
+	ErrNumberParse      = &Error{Code: 23, Offset: -1, msg: "parse error: value cannot be converted to number."}
+	ErrNumberOverflow   = &Error{Code: 24, Offset: -1, msg: "parse error: value overflows the number type."}
+	ErrBadFloat         = &Error{Code: 25, Offset: -1, msg: "error: NaN and Inf are not valid JSON numbers."}
+	ErrUnmarshalType    = &Error{Code: 26, Offset: -1, msg: "error: value cannot be assigned to the field."}
+	ErrBadTarget        = &Error{Code: 27, Offset: -1, msg: "error: target must be a non-nil pointer."}
+	ErrBase64           = &Error{Code: 28, Offset: -1, msg: "parse error: value is not a valid base64 string."}
+	ErrMarshal          = &Error{Code: 29, Offset: -1, msg: "error: value cannot be marshaled to JSON."}
+	ErrTimeParse        = &Error{Code: 30, Offset: -1, msg: "parse error: value cannot be converted to time."}
+	ErrDurationParse    = &Error{Code: 31, Offset: -1, msg: "parse error: value cannot be converted to duration."}
+	ErrValueTooLarge    = &Error{Code: 32, Offset: -1, msg: "error: value is larger than the buffer limit."}
+	ErrLengthMismatch   = &Error{Code: 33, Offset: -1, msg: "error: keys and values must have the same length."}
+)
+
+// Error returns the error message.
+// Message ends with error code, expected token, path segment,
+// position and offset are included if they are known.
+func (e *Error) Error() string {
+	var sb strings.Builder
+	sb.WriteString(e.msg)
+	if e.Expected != "" {
+		fmt.Fprintf(&sb, " expected %v", e.Expected)
+	}
+	if e.Segment != "" {
+		fmt.Fprintf(&sb, " path:'%v'", e.Segment)
+	}
+	if e.Line > 0 {
+		fmt.Fprintf(&sb, " line:'%v' column:'%v'", e.Line, e.Column)
+	}
+	if e.Offset >= 0 {
+		fmt.Fprintf(&sb, " at:'%v'", e.Offset)
+	}
+	fmt.Fprintf(&sb, " error_code:%02d ", e.Code)
+	return sb.String()
+}
+
+// Is reports whether target is an *Error with the same error code.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	return t.Code == e.Code
+}
+
+// at sets the offset and path segment of the error.
+func (e *Error) at(offset int, segment string) *Error {
+	e.Offset = offset
+	e.Segment = segment
+	return e
+}
+
+// in sets the offset, line, column and excerpt of the error from the JSON that error occurred.
+func (e *Error) in(json []byte, offset int) *Error {
+	if offset > len(json) {
+		offset = len(json)
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	e.Offset = offset
+	lineStart := 0
+	e.Line = 1
+	for i := 0; i < offset; i++ {
+		// 10 = NL
+		if json[i] == 10 {
+			e.Line++
+			lineStart = i + 1
+		}
+	}
+	e.Column = utf8.RuneCount(json[lineStart:offset]) + 1
+	lineEnd := offset
+	for lineEnd < len(json) && json[lineEnd] != 10 && json[lineEnd] != 13 {
+		lineEnd++
+	}
+	// keep the excerpt short, at most 32 bytes around the offset.
+	start := lineStart
+	if offset-start > 32 {
+		start = offset - 32
+		for start < offset && !utf8.RuneStart(json[start]) {
+			start++
+		}
+	}
+	end := lineEnd
+	if end-offset > 32 {
+		end = offset + 32
+		for end > offset && end < len(json) && !utf8.RuneStart(json[end]) {
+			end--
+		}
+	}
+	var sb strings.Builder
+	for _, r := range string(json[start:end]) {
+		// tabs kept for caret alignment, other control characters replaced with space.
+		if r < 32 && r != 9 {
+			r = 32
+		}
+		sb.WriteRune(r)
+	}
+	sb.WriteByte(10)
+	for _, r := range string(json[start:offset]) {
+		if r == 9 {
+			sb.WriteByte(9)
+			continue
+		}
+		sb.WriteByte(32)
+	}
+	sb.WriteByte(94)
+	e.Excerpt = sb.String()
+	return e
+}
+
+func newError(sentinel *Error) *Error {
+	return &Error{Code: sentinel.Code, Offset: -1, msg: sentinel.msg}
+}
+
+func newValueError(sentinel *Error, format string, val string) *Error {
+	return &Error{Code: sentinel.Code, Offset: -1, msg: fmt.Sprintf(format, val)}
+}
+
+func nullPathError() *Error {
+	return newError(ErrNullPath)
+}
+func nullNewValueError() *Error {
+	return newError(ErrNullNewValue)
+}
+func emptyArrayError() *Error {
+	return newError(ErrEmptyArray)
+}
+func indexExpectedError() *Error {
+	return newError(ErrIndexExpected)
+}
+func keyExpectedError() *Error {
+	return newError(ErrKeyExpected)
+}
+func objectExpectedError() *Error {
+	return newError(ErrObjectExpected)
+}
+func arrayExpectedError() *Error {
+	return newError(ErrArrayExpected)
+}
+func indexOutOfRangeError() *Error {
+	return newError(ErrIndexOutOfRange)
+}
+func keyNotFoundError() *Error {
+	return newError(ErrKeyNotFound)
+}
+func badJSONError(json []byte, offset int, expected string) *Error {
+	e := newError(ErrBadJSON).in(json, offset)
+	e.Expected = expected
+	return e
+}
+func badKeyError() *Error {
+	return newError(ErrBadKey)
+}
+func keyAlreadyExistsError() *Error {
+	return newError(ErrKeyAlreadyExists)
+}
+func intParseError(val string) *Error {
+	return newValueError(ErrIntParse, "parse error: '%v' cannot be converted to int.", val)
+}
+func floatParseError(val string) *Error {
+	return newValueError(ErrFloatParse, "parse error: '%v' cannot be converted to float.", val)
+}
+func boolParseError(val string) *Error {
+	return newValueError(ErrBoolParse, "parse error: '%v' cannot be converted to bool.", val)
+}
+func stringArrayParseError(val string) *Error {
+	return newValueError(ErrStringArrayParse, "parse error: '%v' cannot be converted to []string.", val)
+}
+func intArrayParseError(val string) *Error {
+	return newValueError(ErrIntArrayParse, "parse error: '%v' cannot be converted to []int.", val)
+}
+func floatArrayParseError(val string) *Error {
+	return newValueError(ErrFloatArrayParse, "parse error: '%v' cannot be converted to []float.", val)
+}
+func boolArrayParseError(val string) *Error {
+	return newValueError(ErrBoolArrayParse, "parse error: '%v' cannot be converted to []bool.", val)
 }
-func emptyArrayError() error {
-	return errors.New("error: array is empty error_code:02 ")
+func nullKeyError() *Error {
+	return newError(ErrNullKey)
 }
-func indexExpectedError() error {
-	return errors.New("error: index expected, got key value error_code:03 ")
+func generalEmptyError() *Error {
+	return newError(ErrEmpty)
 }
-func keyExpectedError() error {
-	return errors.New("error: key expected, got index error_code:04 ")
+func badPathError(expr string, at int) *Error {
+	return newValueError(ErrBadPath, "error: bad path expression '%v'.", expr).at(at, "")
 }
-func objectExpectedError() error {
-	return errors.New("error: last path must be pointed at an object error_code:05 ")
+func badPointerError(pointer string, at int) *Error {
+	return newValueError(ErrBadPointer, "error: bad json pointer '%v'.", pointer).at(at, "")
 }
-func arrayExpectedError() error {
-	return errors.New("error: last path must be pointed at an array error_code:06 ")
+func numberParseError(val string) *Error {
+	return newValueError(ErrNumberParse, "parse error: '%v' cannot be converted to number.", val)
 }
-func indexOutOfRangeError() error {
-	return errors.New("error: index out of range error_code:07 ")
+func numberOverflowError(val string, typeName string) *Error {
+	return newValueError(ErrNumberOverflow, "parse error: '%v' overflows "+typeName+".", val)
 }
-func keyNotFoundError() error {
-	return errors.New("error: key not found error_code:08 ")
+func badFloatError(val string) *Error {
+	return newValueError(ErrBadFloat, "error: '%v' is not a valid JSON number.", val)
 }
-func badJSONError(val int) error {
-	return fmt.Errorf("error: bad json format. at:'%v' error_code:09 ", val)
+func unmarshalTypeError(kind Kind, typeName string) *Error {
+	return newValueError(ErrUnmarshalType, "error: %v value cannot be assigned to "+typeName+".", kind.String())
 }
-func badKeyError() error {
-	return errors.New("error: key value cannot contain quote symbol. error_code:10")
+func lengthMismatchError(keys, values int) *Error {
+	return newValueError(ErrLengthMismatch, "error: keys and values must have the same length, %v keys "+strconv.Itoa(values)+" values.", strconv.Itoa(keys))
 }
-func keyAlreadyExistsError() error {
-	return errors.New("error: key already exist error_code:11 ")
+func embeddedPointerError(typeName string) *Error {
+	return newValueError(ErrUnmarshalType, "error: nil embedded pointer to unexported struct '%v' cannot be allocated.", typeName)
 }
-func intParseError(val string) error {
-	return fmt.Errorf("parse error: '%v' cannot be converted to int. error_code:12", val)
+func badTargetError(typeName string) *Error {
+	return newValueError(ErrBadTarget, "error: target must be a non-nil pointer, got '%v'.", typeName)
 }
-func floatParseError(val string) error {
-	return fmt.Errorf("parse error: '%v' cannot be converted to float. error_code:13", val)
+func marshalError(typeName string) *Error {
+	return newValueError(ErrMarshal, "error: '%v' value cannot be marshaled to JSON.", typeName)
 }
-func boolParseError(val string) error {
-	return fmt.Errorf("parse error: '%v' cannot be converted to bool. error_code:14", val)
+func marshalDepthError(typeName string) *Error {
+	return newValueError(ErrMarshal, "error: '%v' value is nested too deep, it may be cyclic.", typeName)
 }
-func stringArrayParseError(val string) error {
-	return fmt.Errorf("parse error: '%v' cannot be converted to []string. error_code:15", val)
+func marshalerError(typeName string, err error) *Error {
+	return &Error{Code: ErrMarshal.Code, Offset: -1, msg: fmt.Sprintf("error: marshaler of '%v' failed, %v", typeName, err)}
 }
-func intArrayParseError(val string) error {
-	return fmt.Errorf("parse error: '%v' cannot be converted to []int. error_code:16", val)
+func unmarshalerError(typeName string, err error) *Error {
+	return &Error{Code: ErrUnmarshalType.Code, Offset: -1, msg: fmt.Sprintf("error: unmarshaler of '%v' failed, %v", typeName, err)}
 }
-func floatArrayParseError(val string) error {
-	return fmt.Errorf("parse error: '%v' cannot be converted to []float. error_code:17", val)
+func base64Error(val string) *Error {
+	return newValueError(ErrBase64, "parse error: '%v' is not a valid base64 string.", val)
 }
-func boolArrayParseError(val string) error {
-	return fmt.Errorf("parse error: '%v' cannot be converted to []bool. error_code:18", val)
+func timeParseError(val string, layout string) *Error {
+	return newValueError(ErrTimeParse, "parse error: '%v' cannot be converted to time with layout '"+layout+"'.", val)
 }
-func nullKeyError() error {
-	return errors.New("error: new key cannot be null error_code:19 ")
+func durationParseError(val string) *Error {
+	return newValueError(ErrDurationParse, "parse error: '%v' cannot be converted to duration.", val)
 }
-func generalEmptyError() error {
-	return errors.New("error: Object/Array is empty error_code:20 ")
+func valueTooLargeError(limit int) *Error {
+	return newValueError(ErrValueTooLarge, "error: value is larger than the buffer limit, %v bytes.", strconv.Itoa(limit))
 }
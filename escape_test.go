@@ -0,0 +1,124 @@
+package jin
+
+import (
+	"testing"
+)
+
+func TestUnescape(t *testing.T) {
+	tests := []struct {
+		str  string
+		want string
+	}{
+		{``, ``},
+		{`plain`, `plain`},
+		{`a\"b`, `a"b`},
+		{`a\\b`, `a\b`},
+		{`a\/b`, `a/b`},
+		{`\b\f\n\r\t`, "\b\f\n\r\t"},
+		{`\u00e9`, "\u00e9"},
+		{`\u00E9\u0041`, "\u00e9A"},
+		{`\u20ac`, "\u20ac"},
+		{`\ud83d\ude00`, "\U0001F600"},
+		{`x\uD83D\uDE00y`, "x\U0001F600y"},
+		// lone surrogates
+		{`\ud83d`, "\uFFFD"},
+		{`\ud83dx`, "\uFFFDx"},
+		{`\ud83d\u0041`, "\uFFFDA"},
+		{`\ude00`, "\uFFFD"},
+		{`\ude00\ud83d`, "\uFFFD\uFFFD"},
+		{`\ud83d\ud83d\ude00`, "\uFFFD\U0001F600"},
+		// invalid escapes are kept
+		{`\x`, `\x`},
+		{`a\`, `a\`},
+		{`\u12`, `\u12`},
+		{`\u12g4`, `\u12g4`},
+		{`\u`, `\u`},
+	}
+	for _, test := range tests {
+		if got := string(unescape([]byte(test.str))); got != test.want {
+			t.Errorf("%q: got %q, want %q", test.str, got, test.want)
+		}
+	}
+}
+
+func TestCleanString(t *testing.T) {
+	tests := []struct {
+		str  string
+		want string
+	}{
+		{`"a\nb"`, "a\nb"},
+		{` "a\u00e9" `, "a\u00e9"},
+		{`""`, ``},
+		{` 42 `, `42`},
+		{`"a\"`, `a\`},
+		{`"`, `"`},
+		{`"\ud83d\ude00"`, "\U0001F600"},
+		{`true`, `true`},
+	}
+	for _, test := range tests {
+		if got := string(cleanString([]byte(test.str))); got != test.want {
+			t.Errorf("%q: got %q, want %q", test.str, got, test.want)
+		}
+	}
+}
+
+func TestGetStringEscapes(t *testing.T) {
+	json := []byte(`{"s":"a\/b\bc\fd","e":"\ud83d\ude00","l":"\ud83d!","i":"\q","n":12,"a\"b":"quoted","\u00e9":"e"}`)
+	pars, err := Parse(json)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		path []string
+		want string
+		raw  string
+	}{
+		{[]string{"s"}, "a/b\bc\fd", `a\/b\bc\fd`},
+		{[]string{"e"}, "\U0001F600", `\ud83d\ude00`},
+		{[]string{"l"}, "\uFFFD!", `\ud83d!`},
+		{[]string{"i"}, `\q`, `\q`},
+		{[]string{"n"}, `12`, `12`},
+		// keys match with their decoded form.
+		{[]string{`a"b`}, "quoted", "quoted"},
+		{[]string{`a\"b`}, "quoted", "quoted"},
+		{[]string{"\u00e9"}, "e", "e"},
+	}
+	for _, test := range tests {
+		got, err := GetString(json, test.path...)
+		parsGot, parsErr := pars.GetString(test.path...)
+		if err != nil || parsErr != nil || got != test.want || parsGot != test.want {
+			t.Errorf("%q: got %q %v and %q %v, want %q", test.path, got, err, parsGot, parsErr, test.want)
+		}
+		raw, err := GetStringRaw(json, test.path...)
+		parsRaw, parsErr := pars.GetStringRaw(test.path...)
+		if err != nil || parsErr != nil || raw != test.raw || parsRaw != test.raw {
+			t.Errorf("%q: got raw %q %v and %q %v, want %q", test.path, raw, err, parsRaw, parsErr, test.raw)
+		}
+	}
+}
+
+func TestKeyMatch(t *testing.T) {
+	tests := []struct {
+		raw  string
+		key  string
+		want bool
+	}{
+		{`a`, `a`, true},
+		{`a`, `b`, false},
+		{`a\"b`, `a"b`, true},
+		{`a\"b`, `a\"b`, true},
+		{`a\/b`, `a/b`, true},
+		{`\u00e9`, "\u00e9", true},
+		{`\ud83d\ude00`, "\U0001F600", true},
+		{`a\\b`, `a\b`, true},
+		{`a\\b`, `a\\b`, true},
+		{`a\nb`, "a\nb", true},
+		{`a\nb`, `anb`, false},
+		{`ab`, `a\b`, false},
+	}
+	for _, test := range tests {
+		if got := keyMatch(test.raw, test.key); got != test.want {
+			t.Errorf("%q %q: got %v, want %v", test.raw, test.key, got, test.want)
+		}
+	}
+}
@@ -1,6 +1,14 @@
 package jin
 
-import "fmt"
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+)
 
 func ExampleGet() {
 	path := []string{"following", "social"}
@@ -363,7 +371,7 @@ func ExampleScheme_MakeScheme() {
 	json, _ = AddKeyValueString(json, "name", "eco")
 	json, _ = AddKeyValueString(json, "lastname", "hub")
 	json, _ = AddKeyValueString(json, "age", "28")
-	// json = {"name":"eco","lastname":"hub","age":28}
+	// json = {"name":"eco","lastname":"hub","age":"28"}
 
 	// with Scheme
 	person := MakeScheme("name", "lastname", "age")
@@ -534,3 +542,529 @@ func ExampleParser_Add() {
 	fmt.Println(string(json))
 	// Output: {"user":"eco","links":["github.com/ecoshub","godoc.org/github.com/ecoshub"]}
 }
+
+func ExampleParsePath() {
+	json := []byte(`{"repo":{"name":["jin","jsonparser"],"weird.key":"dotted"}}`)
+
+	path, err := ParsePath(`$.repo.name[1]`)
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+	value, err := Get(json, path...)
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+	fmt.Println(string(value))
+
+	value, err = Get(json, MustParsePath(`$.repo['weird.key']`)...)
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+	fmt.Println(string(value))
+	fmt.Println(FormatPath("repo", "weird.key", "0"))
+	// Output: jsonparser
+	//dotted
+	//$.repo['weird.key'][0]
+}
+
+func ExampleQuery() {
+	json := []byte(`{"items":[{"id":1,"tags":["a"]},{"id":2},{"id":3,"child":{"id":4}}]}`)
+
+	matches, err := Query(json, `$.items[*].id`)
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+	for _, m := range matches {
+		fmt.Println(FormatPath(m.Path...), string(m.Value))
+	}
+
+	matches, err = Query(json, `$..id`)
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+	fmt.Println(len(matches))
+
+	matches, err = Query(json, `$.items[1:]`)
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+	for _, m := range matches {
+		fmt.Println(string(m.Value))
+	}
+	// Output: $.items[0].id 1
+	//$.items[1].id 2
+	//$.items[2].id 3
+	//4
+	//{"id":2}
+	//{"id":3,"child":{"id":4}}
+}
+
+func ExampleQuery_filter() {
+	json := []byte(`{"items":[
+		{"name":"pen","price":4,"tags":["sale"]},
+		{"name":"lamp","price":45,"tags":["sale","home"]},
+		{"name":"desk","price":150,"tags":["home"]}
+	]}`)
+
+	matches, err := Query(json, `$.items[?(@.price > 10 && @.tags contains "sale")].name`)
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+	for _, m := range matches {
+		fmt.Println(string(m.Value))
+	}
+	// Output: lamp
+}
+
+func ExampleGetPointer() {
+	json := []byte(`{"repo":{"name":["jin","jsonparser"]},"a/b":{"m~n":42}}`)
+
+	value, err := GetPointer(json, "/repo/name/1")
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+	fmt.Println(string(value))
+
+	value, err = GetPointer(json, "/a~1b/m~0n")
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+	fmt.Println(string(value))
+	fmt.Println(FormatPointer("a/b", "m~n"))
+	// Output: jsonparser
+	//42
+	///a~1b/m~0n
+}
+
+func ExampleGet_negativeIndex() {
+	json := []byte(`{"user":"eco","languages":["go","java","python","C","Cpp"]}`)
+
+	value, err := Get(json, "languages", "-1")
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+	fmt.Println(string(value))
+
+	json, err = AddString(json, "rust", "languages", "-")
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+	fmt.Println(string(json))
+	// Output: Cpp
+	//{"user":"eco","languages":["go","java","python","C","Cpp","rust"]}
+}
+
+func ExampleError() {
+	json := []byte(`{"repo":{"name":"jin"}}`)
+
+	_, err := Get(json, "repo", "owner")
+	if errors.Is(err, ErrKeyNotFound) {
+		fmt.Println("key not found")
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		fmt.Println(e.Code, e.Segment)
+	}
+	// Output: key not found
+	//8 owner
+}
+
+func ExampleError_badJSON() {
+	json := []byte("{\n  \"name\": \"jin\",,\n  \"stars\": 10\n}")
+
+	_, err := Query(json, "$.stars")
+	var e *Error
+	if errors.As(err, &e) {
+		fmt.Println(e.Line, e.Column, e.Expected)
+		fmt.Println(e.Excerpt)
+	}
+	// Output: 2 17 '"'
+	//   "name": "jin",,
+	//                 ^
+}
+
+func ExampleValid() {
+	fmt.Println(Valid([]byte(`{"user":"eco","languages":["go","java"]}`)))
+	fmt.Println(Valid([]byte(`{"a":tru,,}`)))
+	// Output: true
+	//false
+}
+
+func ExampleValidateDetailed() {
+	json := []byte(`{"a":tru,,}`)
+
+	err := ValidateDetailed(json)
+	var e *Error
+	if errors.As(err, &e) {
+		fmt.Println(e.Offset, e.Expected)
+	}
+	// Output: 8 'true'
+}
+
+func ExampleGetString_escapes() {
+	json := []byte(`{"quote":"\"café\"\n","smile":"\ud83d\ude00"}`)
+
+	quote, _ := GetString(json, "quote")
+	smile, _ := GetString(json, "smile")
+	raw, _ := GetStringRaw(json, "quote")
+	fmt.Printf("%q %v %v\n", quote, smile, raw)
+	// Output: "\"café\"\n" 😀 \"café\"\n
+}
+
+func ExampleQuote() {
+	json := []byte(`{"title":""}`)
+
+	json, _ = SetString(json, `say "hi"`+"\n", "title")
+	json, _ = AddKeyValueString(json, `"quoted" key`, "<b>")
+	fmt.Println(string(json))
+	fmt.Println(QuoteHTML("<b>"))
+	// Output: {"title":"say \"hi\"\n","\"quoted\" key":"<b>"}
+	//"\u003cb\u003e"
+}
+
+func ExampleGetNumber() {
+	json := []byte(`{"id":9007199254740993,"amount":"12.345678901234567890","big":123456789012345678901234567890,"ids":[1,18446744073709551615]}`)
+
+	id, _ := GetInt64(json, "id")
+	amount, _ := GetNumber(json, "amount")
+	big, _ := GetBigInt(json, "big")
+	ids, _ := GetUint64Array(json, "ids")
+	_, err := GetInt64(json, "big")
+	fmt.Println(id, amount, big, ids)
+	fmt.Println(errors.Is(err, ErrNumberOverflow))
+	// Output: 9007199254740993 12.345678901234567890 123456789012345678901234567890 [1 18446744073709551615]
+	//true
+}
+
+func ExampleFormatFloat() {
+	json := []byte(`{"price":0}`)
+
+	json, _ = SetFloat(json, 12.5, "price")
+	fmt.Println(string(json))
+
+	fixed, _ := FormatFloat(12.5, 2)
+	json, _ = Set(json, []byte(fixed), "price")
+	fmt.Println(string(json))
+
+	_, err := SetFloat(json, math.NaN(), "price")
+	fmt.Println(errors.Is(err, ErrBadFloat))
+	fmt.Println(string(MakeArray(0.1, float32(0.1), 1e-7, 1e21)))
+	// Output: {"price":12.5}
+	//{"price":12.50}
+	//true
+	//[0.1,0.1,1e-7,1e+21]
+}
+
+func ExampleIsNull() {
+	json := []byte(`{"name":"null","deleted_at":null,"tags":["a"]}`)
+
+	name, _ := IsNull(json, "name")
+	deleted, _ := IsNull(json, "deleted_at")
+	fmt.Println(name, deleted)
+
+	json, _ = SetNull(json, "name")
+	json, _ = AddNull(json, "tags")
+	json, _ = AddKeyValueNull(json, "owner")
+	fmt.Println(string(json))
+	fmt.Println(string(MakeJson([]string{"a", "b"}, []interface{}{nil, 1})))
+	// Output: false true
+	//{"name":null,"deleted_at":null,"tags":["a",null],"owner":null}
+	//{"a":null,"b":1}
+}
+
+func ExampleGetKind() {
+	json := []byte(`{"a":"true","b":true,"c":1.5,"d":null,"e":[],"f":{}}`)
+
+	for _, key := range []string{"a", "b", "c", "d", "e", "f"} {
+		kind, _ := GetKind(json, key)
+		fmt.Print(kind, " ")
+	}
+	pars, _ := Parse(json)
+	kind, _ := pars.GetKind("a")
+	fmt.Println(kind == KindString)
+	// Output: string bool number null array object true
+}
+
+func ExampleUnmarshal() {
+	type Repo struct {
+		Name   string         `jin:"repo.name"`
+		Owner  string         `jin:"repo.owner.login"`
+		Tags   []string       `jin:"tags"`
+		First  string         `jin:"tags[0]"`
+		Labels map[string]int `jin:"labels"`
+		Stars  uint8
+	}
+	json := []byte(`{"repo":{"name":"jin","owner":{"login":"ecoshub"}},"tags":["json","go"],"labels":{"bug":3},"stars":"42"}`)
+
+	var repo Repo
+	err := Unmarshal(json, &repo)
+	fmt.Println(err)
+	fmt.Printf("%+v\n", repo)
+
+	err = Unmarshal([]byte(`{"stars":300}`), &repo)
+	fmt.Println(err.(*Error).Segment, errors.Is(err, ErrNumberOverflow))
+	// Output: <nil>
+	//{Name:jin Owner:ecoshub Tags:[json go] First:json Labels:map[bug:3] Stars:42}
+	//Stars true
+}
+
+func ExampleMarshal() {
+	type Repo struct {
+		Name   string            `jin:"repo.name"`
+		Owner  string            `jin:"repo.owner.login"`
+		Tags   []string          `jin:"tags,omitempty"`
+		Labels map[string]int    `jin:"labels"`
+		Meta   map[string]string `jin:"meta,omitempty"`
+		Stars  *int
+	}
+	stars := 42
+	repo := Repo{Name: "jin", Owner: "ecoshub", Tags: []string{"json"}, Labels: map[string]int{"feature": 2, "bug": 3}, Stars: &stars}
+
+	json, err := Marshal(repo)
+	fmt.Println(string(json), err)
+
+	var back Repo
+	err = Unmarshal(json, &back)
+	fmt.Println(back.Owner, *back.Stars, err)
+
+	fmt.Println(string(MakeArray([]int{1, 2}, map[string]bool{"ok": true}, "42", &stars, nil)))
+	// Output: {"repo":{"name":"jin","owner":{"login":"ecoshub"}},"tags":["json"],"labels":{"bug":3,"feature":2},"Stars":42} <nil>
+	//ecoshub 42 <nil>
+	//[[1,2],{"ok":true},42,42,null]
+}
+
+func ExampleGetAs() {
+	json := []byte(`{"user":{"id":"42","tags":["go","json"],"scores":{"math":90}}}`)
+
+	id, _ := GetAs[int64](json, "user", "id")
+	tags, _ := GetAs[[]string](json, "user", "tags")
+	scores, _ := GetAs[map[string]int](json, "user", "scores")
+	fmt.Println(id, tags, scores)
+
+	_, err := GetAs[uint8](json, "user", "scores", "math")
+	fmt.Println(err)
+	_, err = GetAs[int8](json, "user", "tags", "0")
+	fmt.Println(errors.Is(err, ErrIntParse))
+
+	json, _ = SetAs(json, []int{1, 2}, "user", "tags")
+	pars, _ := Parse(json)
+	ParserSetAs(pars, map[string]bool{"admin": true}, "user", "scores")
+	json, _ = pars.Get()
+	fmt.Println(string(json))
+	// Output: 42 [go json] map[math:90]
+	//<nil>
+	//true
+	//{"user":{"id":"42","tags":[1,2],"scores":{"admin":true}}}
+}
+
+func ExampleGetIntOr() {
+	config := []byte(`{"server":{"port":"8080","timeout":null,"debug":"yes"}}`)
+
+	logError := ErrorHandler(func(err error, path []string) {
+		fmt.Println(path, errors.Is(err, ErrBoolParse))
+	})
+
+	port := GetIntOr(config, 80, "server", "port")
+	timeout := GetIntOr(config, 30, "server", "timeout")
+	host := GetStringOr(config, "localhost", "server", "host")
+	debug := logError.GetBoolOr(config, false, "server", "debug")
+	ports := GetAsOrHandler(config, []int{80}, logError, "server", "ports")
+	fmt.Println(port, timeout, host, debug, ports)
+	// Output: [server debug] true
+	//8080 30 localhost false [80]
+}
+
+func ExampleCoercion() {
+	json := []byte(`{"id":"42","score":42.9,"active":1,"verified":"TRUE","ports":["80",443.0]}`)
+
+	id, err := StrictCoercion.GetInt(json, "id")
+	fmt.Println(id, errors.Is(err, ErrIntParse))
+
+	id, _ = LooseCoercion.GetInt(json, "id")
+	score, _ := LooseCoercion.GetInt(json, "score")
+	active, _ := LooseCoercion.GetBool(json, "active")
+	fmt.Println(id, score, active)
+
+	exact := Coercion{StringToNumber: true, FloatToInt: FloatExact}
+	ports, _ := exact.GetIntArray(json, "ports")
+	_, err = exact.GetInt(json, "score")
+	fmt.Println(ports, err != nil)
+
+	pars, _ := Parse(json)
+	pars.SetCoercion(&LooseCoercion)
+	verified, _ := pars.GetBool("verified")
+	fmt.Println(verified)
+	// Output: -1 true
+	//42 42 true
+	//[80 443] true
+	//true
+}
+
+func ExampleGetTime() {
+	json := []byte(`{"created":"2023-11-14T22:13:20Z","updated":1700000000,"expires":1700000000500,"ttl":"1h30m"}`)
+
+	created, _ := GetTime(json, time.RFC3339, "created")
+	updated, _ := GetTime(json, LayoutUnix, "updated")
+	expires, _ := GetTime(json, LayoutUnixMilli, "expires")
+	ttl, _ := GetDuration(json, "ttl")
+	fmt.Println(created.Equal(updated), expires.Sub(updated), ttl)
+
+	_, err := GetTime(json, time.RFC3339, "ttl")
+	fmt.Println(errors.Is(err, ErrTimeParse))
+
+	json, _ = SetTime(json, created.Add(ttl), LayoutUnix, "updated")
+	json, _ = AddKeyValueTime(json, "deleted", created, "2006-01-02")
+	fmt.Println(string(json))
+	// Output: true 500ms 1h30m0s
+	//true
+	//{"created":"2023-11-14T22:13:20Z","updated":1700005400,"expires":1700000000500,"ttl":"1h30m","deleted":"2023-11-14"}
+}
+
+func ExampleGetBytes() {
+	json := []byte(`{"std":"aGk/Pz4+","url":"aGk_Pz4-","raw":"aGk"}`)
+
+	std, _ := GetBytes(json, "std")
+	url, _ := GetBytes(json, "url")
+	raw, _ := GetBytes(json, "raw")
+	fmt.Println(string(std), string(url), string(raw))
+
+	json, _ = SetBytes(json, []byte("hi??>>"), base64.RawURLEncoding, "std")
+	json, _ = AddKeyValueBytes(json, "sig", []byte{0xde, 0xad}, nil)
+	fmt.Println(string(json))
+	fmt.Println(string(MakeArray([]byte("hi"), "hi")))
+	// Output: hi??>> hi??>> hi
+	//{"std":"aGk_Pz4-","url":"aGk_Pz4-","raw":"aGk","sig":"3q0="}
+	//["aGk=","hi"]
+}
+
+func ExampleDecoder() {
+	export := strings.NewReader(`{"meta":{"count":3},"users":[{"id":1,"name":"eco"},{"id":2,"name":"hub"},{"id":3,"name":"jin"}]}`)
+
+	dec := NewDecoder(export)
+	if err := dec.Find("users"); err != nil {
+		fmt.Println(err)
+		return
+	}
+	for {
+		index, user, err := dec.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		name, _ := GetString(user, "name")
+		fmt.Println(index, name)
+	}
+	// Output: 0 eco
+	//1 hub
+	//2 jin
+}
+
+func ExampleLineReader() {
+	logs := "{\"level\":\"info\",\"msg\":\"started\"}\n\n{\"level\":\"warn\" \"msg\":\"slow\"}\r\n{\"level\":\"error\",\"msg\":\"failed\"}\n"
+
+	lr := NewLineReader(strings.NewReader(logs), LineSkip)
+	for {
+		pars, err := lr.NextParser()
+		if err == io.EOF {
+			break
+		}
+		msg, _ := pars.GetString("msg")
+		fmt.Println(lr.Line(), msg)
+	}
+	fmt.Println("skipped:", lr.Skipped())
+
+	lr = NewLineReader(strings.NewReader(logs), LineFail)
+	lr.Next()
+	_, err := lr.Next()
+	e := err.(*Error)
+	fmt.Println(errors.Is(err, ErrBadJSON), e.Expected, e.Line, e.Column)
+
+	var out strings.Builder
+	lw := NewLineWriter(&out)
+	lw.WriteRecord([]byte("{\n\t\"level\": \"info\",\n\t\"msg\": \"a b\"\n}"))
+	lw.WriteValue(map[string]int{"count": 2})
+	fmt.Print(out.String())
+	// Output: 1 started
+	//4 failed
+	//skipped: 1
+	//true ',' or '}' 3 17
+	//{"level":"info","msg":"a b"}
+	//{"count":2}
+}
+
+func ExampleTokenize() {
+	json := []byte(`{"user":{"name":"eco","password":"s3cr3t"},"tokens":["a\"b",{"password":"x"}],"age":28}`)
+
+	// a redactor, secrets are replaced by copying the document around their offsets.
+	redacted := make([]byte, 0, len(json))
+	last := 0
+	err := Tokenize(json, func(tok Token) bool {
+		if tok.Kind == TokenString && tok.Path[len(tok.Path)-1] == "password" {
+			redacted = append(redacted, json[last:tok.Start]...)
+			redacted = append(redacted, `"***"`...)
+			last = tok.End
+		}
+		return true
+	})
+	redacted = append(redacted, json[last:]...)
+	fmt.Println(string(redacted), err)
+
+	tokenizer := NewTokenizer([]byte(`{"a": [1, true]}`))
+	for {
+		tok, err := tokenizer.Next()
+		if err == io.EOF {
+			break
+		}
+		fmt.Println(tok.Kind, tok.Start, tok.Text(), tok.Path)
+	}
+
+	err = Tokenize([]byte(`{"a":1 "b":2}`), func(tok Token) bool { return true })
+	e := err.(*Error)
+	fmt.Println(errors.Is(err, ErrBadJSON), e.Expected, e.Offset)
+	// Output: {"user":{"name":"eco","password":"***"},"tokens":["a\"b",{"password":"***"}],"age":28} <nil>
+	//BeginObject 0 { []
+	//Key 1 a [a]
+	//BeginArray 6 [ [a]
+	//Number 7 1 [a 0]
+	//Literal 10 true [a 1]
+	//EndArray 14 ] [a]
+	//EndObject 15 } []
+	//true ',' or '}' 7
+}
+
+func ExampleGetMany() {
+	json := []byte(`{"user":{"name":"eco","tags":["go","json"]},"id":42}`)
+	paths := [][]string{{"id"}, {"user", "name"}, {"user", "tags", "1"}, {"user", "email"}}
+
+	values, errs := GetMany(json, paths)
+	for i := range paths {
+		fmt.Printf("%q %v\n", values[i], errors.Is(errs[i], ErrKeyNotFound))
+	}
+
+	found := 0
+	GetManyFunc(json, paths, func(index int, value []byte, err error) {
+		if err == nil {
+			found++
+		}
+	})
+	fmt.Println(found)
+	// Output: "42" false
+	//"eco" false
+	//"json" false
+	//"" true
+	//3
+}
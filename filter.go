@@ -0,0 +1,403 @@
+package jin
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+)
+
+// filter expression node types.
+const (
+	filterOr = iota
+	filterAnd
+	filterNot
+	filterExists
+	filterCompare
+)
+
+// operand kinds, same as the first byte of JSON values they represent.
+const (
+	operandMissing byte = 0
+	operandString  byte = 34
+	operandNumber  byte = 48
+	operandBool    byte = 116
+	operandNull    byte = 110
+	operandValue   byte = 123
+)
+
+// filterNode is a compiled filter expression like '@.price > 10 && @.tags contains "sale"'
+type filterNode struct {
+	op    int
+	left  *filterNode
+	right *filterNode
+	// comparison operator and operands, used by filterCompare and filterExists.
+	cmp string
+	a   filterOperand
+	b   filterOperand
+}
+
+// filterOperand is either a literal or a path relative to current element ('@').
+type filterOperand struct {
+	relative bool
+	path     []string
+	kind     byte
+	value    []byte
+}
+
+// parseFilter compiles a filter expression. offset must point at '?'.
+// returns the compiled expression and offset of the next byte after it.
+func parseFilter(expr string, offset int) (*filterNode, int, error) {
+	p := &filterParser{expr: expr, offset: offset + 1}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, p.offset, err
+	}
+	return node, p.offset, nil
+}
+
+type filterParser struct {
+	expr   string
+	offset int
+}
+
+func (p *filterParser) skip() {
+	p.offset = skipPathSpace(p.expr, p.offset)
+}
+
+func (p *filterParser) consume(token string) bool {
+	p.skip()
+	if len(p.expr)-p.offset >= len(token) && p.expr[p.offset:p.offset+len(token)] == token {
+		p.offset += len(token)
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) parseOr() (*filterNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.consume("||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &filterNode{op: filterOr, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (*filterNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.consume("&&") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &filterNode{op: filterAnd, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (*filterNode, error) {
+	p.skip()
+	// '!' but not '!='
+	if p.offset+1 < len(p.expr) && p.expr[p.offset] == 33 && p.expr[p.offset+1] != 61 {
+		p.offset++
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &filterNode{op: filterNot, left: node}, nil
+	}
+	if p.consume("(") {
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume(")") {
+			return nil, badPathError(p.expr, p.offset)
+		}
+		return node, nil
+	}
+	a, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	for _, cmp := range []string{"==", "!=", "<=", ">=", "<", ">", "contains"} {
+		if p.consume(cmp) {
+			b, err := p.parseOperand()
+			if err != nil {
+				return nil, err
+			}
+			return &filterNode{op: filterCompare, cmp: cmp, a: a, b: b}, nil
+		}
+	}
+	if !a.relative {
+		return nil, badPathError(p.expr, p.offset)
+	}
+	return &filterNode{op: filterExists, a: a}, nil
+}
+
+func (p *filterParser) parseOperand() (filterOperand, error) {
+	p.skip()
+	expr := p.expr
+	if p.offset >= len(expr) {
+		return filterOperand{}, badPathError(expr, p.offset)
+	}
+	curr := expr[p.offset]
+	switch {
+	// 64 = @
+	case curr == 64:
+		p.offset++
+		operand := filterOperand{relative: true, path: []string{}}
+		for p.offset < len(expr) {
+			curr = expr[p.offset]
+			// 46 = .
+			if curr == 46 {
+				start := p.offset + 1
+				end := start
+				for end < len(expr) && isFilterKeyChar(expr[end]) {
+					end++
+				}
+				if end == start {
+					return filterOperand{}, badPathError(expr, end)
+				}
+				operand.path = append(operand.path, expr[start:end])
+				p.offset = end
+				continue
+			}
+			// 91 = [
+			if curr == 91 {
+				segment, next, err := parseBracket(expr, p.offset)
+				if err != nil {
+					return filterOperand{}, err
+				}
+				if segment.kind != segmentKey && segment.kind != segmentIndex {
+					return filterOperand{}, badPathError(expr, p.offset)
+				}
+				operand.path = append(operand.path, segment.value)
+				p.offset = next
+				continue
+			}
+			break
+		}
+		return operand, nil
+	// 34 = ", 39 = '
+	case curr == 34 || curr == 39:
+		str, next, err := parseQuotedKey(expr, p.offset)
+		if err != nil {
+			return filterOperand{}, err
+		}
+		p.offset = next
+		return filterOperand{kind: operandString, value: []byte(str)}, nil
+	// 45 = -, 48-57 = digits
+	case curr == 45 || (curr >= 48 && curr <= 57):
+		start := p.offset
+		end := start + 1
+		for end < len(expr) && isFilterNumberChar(expr[end]) {
+			end++
+		}
+		if _, err := strconv.ParseFloat(expr[start:end], 64); err != nil {
+			return filterOperand{}, badPathError(expr, start)
+		}
+		p.offset = end
+		return filterOperand{kind: operandNumber, value: []byte(expr[start:end])}, nil
+	}
+	for _, literal := range []string{"true", "false", "null"} {
+		if p.consume(literal) {
+			kind := operandBool
+			if literal == "null" {
+				kind = operandNull
+			}
+			return filterOperand{kind: kind, value: []byte(literal)}, nil
+		}
+	}
+	return filterOperand{}, badPathError(expr, p.offset)
+}
+
+func isFilterKeyChar(curr byte) bool {
+	return curr == 95 || curr == 45 || curr == 36 ||
+		(curr >= 48 && curr <= 57) || (curr >= 65 && curr <= 90) || (curr >= 97 && curr <= 122) || curr > 127
+}
+
+func isFilterNumberChar(curr byte) bool {
+	// digits . e E + -
+	return (curr >= 48 && curr <= 57) || curr == 46 || curr == 101 || curr == 69 || curr == 43 || curr == 45
+}
+
+// match evaluates filter expression against a value.
+// Malformed values return an error, its offset is relative to json.
+func (f *filterNode) match(json []byte) (bool, error) {
+	switch f.op {
+	case filterOr:
+		found, err := f.left.match(json)
+		if found || err != nil {
+			return found, err
+		}
+		return f.right.match(json)
+	case filterAnd:
+		found, err := f.left.match(json)
+		if !found || err != nil {
+			return false, err
+		}
+		return f.right.match(json)
+	case filterNot:
+		found, err := f.left.match(json)
+		return !found && err == nil, err
+	case filterExists:
+		kind, _, _ := f.a.resolve(json)
+		return kind != operandMissing, nil
+	case filterCompare:
+		aKind, a, aStart := f.a.resolve(json)
+		bKind, b, _ := f.b.resolve(json)
+		if aKind == operandMissing || bKind == operandMissing {
+			return false, nil
+		}
+		if f.cmp == "contains" {
+			found, err := contains(aKind, a, bKind, b)
+			if err != nil {
+				return false, errorIn(err, json, aStart)
+			}
+			return found, nil
+		}
+		return compareOperands(f.cmp, aKind, a, bKind, b), nil
+	}
+	return false, nil
+}
+
+// resolve returns the kind, value and start offset of operand for the current element.
+// string values returned without quotation marks and with decoded escape sequences.
+// Offset is zero for literals.
+func (o *filterOperand) resolve(json []byte) (byte, []byte, int) {
+	if !o.relative {
+		return o.kind, o.value, 0
+	}
+	var start, end int
+	var err error
+	if len(o.path) == 0 {
+		start = skipSpace(json, 0)
+		end, err = valueEnd(json, start)
+		if err != nil {
+			return operandMissing, nil, 0
+		}
+	} else {
+		_, start, end, err = core(json, false, o.path...)
+		if err != nil {
+			return operandMissing, nil, 0
+		}
+		// core() returns strings without quotation marks.
+		if start > 0 && json[start-1] == 34 && end < len(json) && json[end] == 34 {
+			return operandString, unescape(json[start:end]), start
+		}
+	}
+	kind, value := operandKind(json[start:end])
+	return kind, value, start
+}
+
+// errorIn moves the offset of err, that occurred in a part of json starts at offset, to json.
+func errorIn(err error, json []byte, offset int) error {
+	var e *Error
+	if errors.As(err, &e) {
+		e.in(json, offset+e.Offset)
+	}
+	return err
+}
+
+// operandKind determines the kind of a raw JSON value.
+// Escape sequences of strings are decoded, like literals of filters are.
+func operandKind(value []byte) (byte, []byte) {
+	if len(value) == 0 {
+		return operandMissing, nil
+	}
+	switch value[0] {
+	case 34:
+		return operandString, unescape(value[1 : len(value)-1])
+	case 91, 123:
+		return operandValue, value
+	case 116, 102:
+		return operandBool, value
+	case 110:
+		return operandNull, value
+	}
+	return operandNumber, value
+}
+
+func compareOperands(cmp string, aKind byte, a []byte, bKind byte, b []byte) bool {
+	if aKind != bKind {
+		return cmp == "!="
+	}
+	result := 0
+	switch aKind {
+	case operandNumber:
+		// same semantics with GetFloat()
+		x, err := strconv.ParseFloat(string(a), 64)
+		if err != nil {
+			return false
+		}
+		y, err := strconv.ParseFloat(string(b), 64)
+		if err != nil {
+			return false
+		}
+		if x < y {
+			result = -1
+		} else if x > y {
+			result = 1
+		}
+	case operandString:
+		result = bytes.Compare(a, b)
+	default:
+		// bool, null, object and array values only can be checked for equality.
+		equal := bytes.Equal(Flatten(a), Flatten(b))
+		switch cmp {
+		case "==":
+			return equal
+		case "!=":
+			return !equal
+		}
+		return false
+	}
+	switch cmp {
+	case "==":
+		return result == 0
+	case "!=":
+		return result != 0
+	case "<":
+		return result < 0
+	case "<=":
+		return result <= 0
+	case ">":
+		return result > 0
+	case ">=":
+		return result >= 0
+	}
+	return false
+}
+
+// contains is true if a is a string that contains b,
+// an array that has an element equal to b or an object that has b as key.
+// Malformed arrays and objects return an error instead of false.
+func contains(aKind byte, a []byte, bKind byte, b []byte) (bool, error) {
+	switch aKind {
+	case operandString:
+		return bKind == operandString && bytes.Contains(a, b), nil
+	case operandValue:
+		found := false
+		isObject := a[0] == 123
+		err := eachChild(a, 0, func(label string, start, end int) bool {
+			if isObject {
+				found = bKind == operandString && keyMatch(label, string(b))
+				return !found
+			}
+			kind, value := operandKind(a[start:end])
+			found = compareOperands("==", kind, value, bKind, b)
+			return !found
+		})
+		return found, err
+	}
+	return false, nil
+}
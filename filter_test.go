@@ -0,0 +1,84 @@
+package jin
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFilterOperands(t *testing.T) {
+	json := []byte(`[
+		{"id":0,"a":"x\"y","n":10},
+		{"id":1,"a":"caf\u00e9","n":1e1,"tags":["x\"y","b"]},
+		{"id":2,"a":"b\\c","n":"10","m":{"k\"q":true}},
+		{"id":3,"n":-2.5,"z":null}
+	]`)
+	tests := []struct {
+		filter string
+		ids    string
+	}{
+		// escapes
+		{`@.a == 'x"y'`, "0"},
+		{`@.a == "x\"y"`, "0"},
+		{`@.a == "café"`, "1"},
+		{`@.a == "caf\u00e9"`, "1"},
+		{`@.a == "b\\c"`, "2"},
+		{`@.a contains "é"`, "1"},
+		{`@.tags contains 'x"y'`, "1"},
+		{`@.m contains 'k"q'`, "2"},
+		{`@.a > "c"`, "01"},
+		// numbers
+		{`@.n == 10`, "01"},
+		{`@.n == 1e1`, "01"},
+		{`@.n < 0`, "3"},
+		{`@.n >= -2.5`, "013"},
+		{`@.n == "10"`, "2"},
+		{`@.n != 10`, "23"},
+		// missing operands
+		{`@.a`, "012"},
+		{`!@.a`, "3"},
+		{`@.a == @.missing`, ""},
+		{`@.missing != 1`, ""},
+		{`@.missing < 1 || @.id == 3`, "3"},
+		{`@.z == null`, "3"},
+	}
+	for _, test := range tests {
+		matches, err := Query(json, "$[?("+test.filter+")].id")
+		if err != nil {
+			t.Errorf("%v: unexpected error %v", test.filter, err)
+			continue
+		}
+		ids := ""
+		for _, m := range matches {
+			ids += string(m.Value)
+		}
+		if ids != test.ids {
+			t.Errorf("%v: got ids %q, want %q", test.filter, ids, test.ids)
+		}
+	}
+}
+
+func TestFilterMalformedValues(t *testing.T) {
+	json := []byte(`[{"id":0,"t":[1,,2]},{"id":1,"t":[3]}]`)
+	tests := []string{
+		`@.t contains 2`,
+		`!(@.t contains 2)`,
+		`@.id == 1 || @.t contains 2`,
+		`@.id == 0 && @.t contains 2`,
+	}
+	for _, filter := range tests {
+		matches, err := Query(json, "$[?("+filter+")].id")
+		var e *Error
+		if !errors.As(err, &e) || !errors.Is(err, ErrBadJSON) {
+			t.Errorf("%v: got %v %v, want ErrBadJSON", filter, matches, err)
+			continue
+		}
+		if e.Offset != 16 || e.Column != 17 {
+			t.Errorf("%v: got offset %v column %v, want 16 and 17", filter, e.Offset, e.Column)
+		}
+	}
+	// values are not read after the filter is decided.
+	matches, err := Query(json, "$[?(@.id == 1 && @.t contains 2)].id")
+	if err != nil || len(matches) != 0 {
+		t.Errorf("got %v %v, want no matches", matches, err)
+	}
+}
@@ -0,0 +1,63 @@
+package jin
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestFloatWritersBadFloat(t *testing.T) {
+	object := []byte(`{"a":1,"b":[1]}`)
+	array := []byte(`[1]`)
+	for _, value := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		tests := []struct {
+			name string
+			json []byte
+			call func(json []byte) ([]byte, error)
+		}{
+			{"SetFloat", object, func(json []byte) ([]byte, error) { return SetFloat(json, value, "a") }},
+			{"AddFloat", array, func(json []byte) ([]byte, error) { return AddFloat(json, value) }},
+			{"InsertFloat", array, func(json []byte) ([]byte, error) { return InsertFloat(json, 0, value) }},
+			{"AddKeyValueFloat", object, func(json []byte) ([]byte, error) { return AddKeyValueFloat(json, "c", value) }},
+		}
+		for _, test := range tests {
+			json, err := test.call(test.json)
+			if !errors.Is(err, ErrBadFloat) || string(json) != string(test.json) {
+				t.Errorf("%v(%v): got %s %v, want the input json and ErrBadFloat", test.name, value, json, err)
+			}
+		}
+		pars, _ := Parse(object)
+		for name, err := range map[string]error{
+			"Parser.SetFloat":         pars.SetFloat(value, "a"),
+			"Parser.AddFloat":         pars.AddFloat(value, "b"),
+			"Parser.InsertFloat":      pars.InsertFloat(0, value, "b"),
+			"Parser.AddKeyValueFloat": pars.AddKeyValueFloat("c", value),
+		} {
+			if !errors.Is(err, ErrBadFloat) {
+				t.Errorf("%v(%v): got %v, want ErrBadFloat", name, value, err)
+			}
+		}
+		if got, _ := pars.Get(); string(got) != string(object) {
+			t.Errorf("Parser(%v): got %s, want it unchanged", value, got)
+		}
+	}
+}
+
+func TestFloatWriters(t *testing.T) {
+	json, err := SetFloat([]byte(`{"a":1}`), 12.5, "a")
+	if err != nil || string(json) != `{"a":12.5}` {
+		t.Errorf("got %s %v", json, err)
+	}
+	json, err = AddFloat([]byte(`[1]`), 1e21)
+	if err != nil || string(json) != `[1,1e+21]` {
+		t.Errorf("got %s %v", json, err)
+	}
+	json, err = InsertFloat([]byte(`[1]`), 0, 0.000001)
+	if err != nil || string(json) != `[0.000001,1]` {
+		t.Errorf("got %s %v", json, err)
+	}
+	json, err = AddKeyValueFloat([]byte(`{}`), "a", -0.1)
+	if err != nil || string(json) != `{"a":-0.1}` {
+		t.Errorf("got %s %v", json, err)
+	}
+}
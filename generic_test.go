@@ -0,0 +1,131 @@
+package jin
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestGetAsSameWithTypedGetters(t *testing.T) {
+	json := []byte(`{"null":null,"int":42,"neg":-7,"float":42.5,"exp":4e1,"str":"42","text":"a\"b","true":true,"false":false,"obj":{"a":1},"arr":[1,2]}`)
+	keys := []string{"null", "int", "neg", "float", "exp", "str", "text", "true", "false", "obj", "arr", "missing"}
+	code := func(err error) int {
+		var e *Error
+		if errors.As(err, &e) {
+			return e.Code
+		}
+		return -1
+	}
+	for _, key := range keys {
+		gotInt, errAs := GetAs[int](json, key)
+		wantInt, err := GetInt(json, key)
+		if (err == nil) != (errAs == nil) || (err == nil && gotInt != wantInt) || (err != nil && code(err) != code(errAs)) {
+			t.Errorf("%v: GetAs[int] returned %v %v, GetInt returned %v %v", key, gotInt, errAs, wantInt, err)
+		}
+		gotString, errAs := GetAs[string](json, key)
+		wantString, err := GetString(json, key)
+		if (err == nil) != (errAs == nil) || (err == nil && gotString != wantString) || (err != nil && code(err) != code(errAs)) {
+			t.Errorf("%v: GetAs[string] returned %q %v, GetString returned %q %v", key, gotString, errAs, wantString, err)
+		}
+		gotBool, errAs := GetAs[bool](json, key)
+		wantBool, err := GetBool(json, key)
+		if (err == nil) != (errAs == nil) || (err == nil && gotBool != wantBool) || (err != nil && code(err) != code(errAs)) {
+			t.Errorf("%v: GetAs[bool] returned %v %v, GetBool returned %v %v", key, gotBool, errAs, wantBool, err)
+		}
+	}
+}
+
+func TestGetAsNull(t *testing.T) {
+	json := []byte(`{"a":null}`)
+	if v, err := GetAs[*int](json, "a"); v != nil || err != nil {
+		t.Errorf("GetAs[*int] returned %v %v, want nil pointer", v, err)
+	}
+	if v, err := GetAs[[]string](json, "a"); v != nil || err != nil {
+		t.Errorf("GetAs[[]string] returned %v %v, want nil slice", v, err)
+	}
+	if _, err := GetAs[float64](json, "a"); !errors.Is(err, ErrFloatParse) {
+		t.Errorf("GetAs[float64] returned %v, want ErrFloatParse", err)
+	}
+	if _, err := GetAs[struct{ A int }](json, "a"); !errors.Is(err, ErrUnmarshalType) {
+		t.Errorf("GetAs[struct] returned %v, want ErrUnmarshalType", err)
+	}
+	pars, _ := Parse(json)
+	if _, err := ParserGetAs[int](pars, "a"); !errors.Is(err, ErrIntParse) {
+		t.Errorf("ParserGetAs[int] returned %v, want ErrIntParse", err)
+	}
+}
+
+func TestAddAs(t *testing.T) {
+	json := `{"a":[0,0],"o":{}}`
+	type item struct {
+		Name string `json:"name"`
+		Tags []string
+	}
+	var nilPtr *item
+	tests := []struct {
+		name string
+		add  func(json []byte) ([]byte, error)
+		pars func(p *Parser) error
+		want string
+		err  error
+	}{
+		{"int",
+			func(json []byte) ([]byte, error) { return AddAs(json, 42, "a") },
+			func(p *Parser) error { return ParserAddAs(p, 42, "a") },
+			`{"a":[0,0,42],"o":{}}`, nil},
+		{"string",
+			func(json []byte) ([]byte, error) { return AddAs(json, "42", "a") },
+			func(p *Parser) error { return ParserAddAs(p, "42", "a") },
+			`{"a":[0,0,"42"],"o":{}}`, nil},
+		{"struct",
+			func(json []byte) ([]byte, error) { return AddAs(json, item{"x", []string{"y"}}, "a") },
+			func(p *Parser) error { return ParserAddAs(p, item{"x", []string{"y"}}, "a") },
+			`{"a":[0,0,{"name":"x","Tags":["y"]}],"o":{}}`, nil},
+		{"slice",
+			func(json []byte) ([]byte, error) { return AddAs(json, []float64{1.5, 2}, "a") },
+			func(p *Parser) error { return ParserAddAs(p, []float64{1.5, 2}, "a") },
+			`{"a":[0,0,[1.5,2]],"o":{}}`, nil},
+		{"nil pointer",
+			func(json []byte) ([]byte, error) { return AddAs(json, nilPtr, "a") },
+			func(p *Parser) error { return ParserAddAs(p, nilPtr, "a") },
+			`{"a":[0,0,null],"o":{}}`, nil},
+		{"append position",
+			func(json []byte) ([]byte, error) { return AddAs(json, true, "a", "-") },
+			func(p *Parser) error { return ParserAddAs(p, true, "a", "-") },
+			`{"a":[0,0,true],"o":{}}`, nil},
+		{"key value",
+			func(json []byte) ([]byte, error) { return AddKeyValueAs(json, "k", map[string]int{"b": 1}, "o") },
+			func(p *Parser) error { return ParserAddKeyValueAs(p, "k", map[string]int{"b": 1}, "o") },
+			`{"a":[0,0],"o":{"k":{"b":1}}}`, nil},
+		{"bad float",
+			func(json []byte) ([]byte, error) { return AddAs(json, math.NaN(), "a") },
+			func(p *Parser) error { return ParserAddAs(p, math.NaN(), "a") },
+			``, ErrBadFloat},
+		{"channel",
+			func(json []byte) ([]byte, error) { return AddAs(json, make(chan int), "a") },
+			func(p *Parser) error { return ParserAddAs(p, make(chan int), "a") },
+			``, ErrMarshal},
+		{"missing",
+			func(json []byte) ([]byte, error) { return AddAs(json, 1, "x") },
+			func(p *Parser) error { return ParserAddAs(p, 1, "x") },
+			``, ErrKeyNotFound},
+	}
+	for _, test := range tests {
+		got, err := test.add([]byte(json))
+		pars, perr := Parse([]byte(json))
+		if perr != nil {
+			t.Fatal(perr)
+		}
+		parsErr := test.pars(pars)
+		if test.err != nil {
+			if !errors.Is(err, test.err) || !errors.Is(parsErr, test.err) {
+				t.Errorf("%v: got %v and %v, want %v", test.name, err, parsErr, test.err)
+			}
+			continue
+		}
+		parsGot, _ := pars.Get()
+		if err != nil || parsErr != nil || string(got) != test.want || string(parsGot) != test.want {
+			t.Errorf("%v: got %s %v and %s %v, want %v", test.name, got, err, parsGot, parsErr, test.want)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package jin
+
+import (
+	"errors"
+	"testing"
+)
+
+// indexOp is a write or read with a negative index or '-' position,
+// run runs it with the interpreter and apply runs it on a Parser.
+type indexOp struct {
+	name  string
+	run   func(json []byte) ([]byte, error)
+	apply func(p *Parser) ([]byte, error)
+}
+
+func parserResult(p *Parser, err error) ([]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+	return p.Get()
+}
+
+func getAt(path ...string) indexOp {
+	return indexOp{
+		name:  "Get",
+		run:   func(json []byte) ([]byte, error) { return Get(json, path...) },
+		apply: func(p *Parser) ([]byte, error) { return p.Get(path...) },
+	}
+}
+
+func setAt(value string, path ...string) indexOp {
+	return indexOp{
+		name: "Set",
+		run:  func(json []byte) ([]byte, error) { return Set(json, []byte(value), path...) },
+		apply: func(p *Parser) ([]byte, error) {
+			return parserResult(p, p.Set([]byte(value), path...))
+		},
+	}
+}
+
+func deleteAt(path ...string) indexOp {
+	return indexOp{
+		name:  "Delete",
+		run:   func(json []byte) ([]byte, error) { return Delete(json, path...) },
+		apply: func(p *Parser) ([]byte, error) { return parserResult(p, p.Delete(path...)) },
+	}
+}
+
+func insertAt(index int, value string, path ...string) indexOp {
+	return indexOp{
+		name: "Insert",
+		run:  func(json []byte) ([]byte, error) { return Insert(json, index, []byte(value), path...) },
+		apply: func(p *Parser) ([]byte, error) {
+			return parserResult(p, p.Insert(index, []byte(value), path...))
+		},
+	}
+}
+
+func insertIntAt(index int, value int, path ...string) indexOp {
+	return indexOp{
+		name: "InsertInt",
+		run:  func(json []byte) ([]byte, error) { return InsertInt(json, index, value, path...) },
+		apply: func(p *Parser) ([]byte, error) {
+			return parserResult(p, p.InsertInt(index, value, path...))
+		},
+	}
+}
+
+func addAt(value string, path ...string) indexOp {
+	return indexOp{
+		name:  "Add",
+		run:   func(json []byte) ([]byte, error) { return Add(json, []byte(value), path...) },
+		apply: func(p *Parser) ([]byte, error) { return parserResult(p, p.Add([]byte(value), path...)) },
+	}
+}
+
+func TestNegativeIndex(t *testing.T) {
+	json := `{"a":[1,2,3],"b":[{"c":4},{"c":5}],"e":[]}`
+	tests := []struct {
+		op   indexOp
+		want string
+		err  error
+	}{
+		{getAt("a", "-1"), `3`, nil},
+		{getAt("a", "-3"), `1`, nil},
+		{getAt("b", "-1", "c"), `5`, nil},
+		{getAt("b", "-2", "c"), `4`, nil},
+		{getAt("a", "-4"), ``, ErrIndexOutOfRange},
+		{getAt("e", "-1"), ``, ErrIndexOutOfRange},
+
+		{setAt(`9`, "a", "-1"), `{"a":[1,2,9],"b":[{"c":4},{"c":5}],"e":[]}`, nil},
+		{setAt(`9`, "a", "-3"), `{"a":[9,2,3],"b":[{"c":4},{"c":5}],"e":[]}`, nil},
+		{setAt(`9`, "b", "-1", "c"), `{"a":[1,2,3],"b":[{"c":4},{"c":9}],"e":[]}`, nil},
+		{setAt(`9`, "a", "-4"), ``, ErrIndexOutOfRange},
+
+		{deleteAt("a", "-1"), `{"a":[1,2],"b":[{"c":4},{"c":5}],"e":[]}`, nil},
+		{deleteAt("a", "-3"), `{"a":[2,3],"b":[{"c":4},{"c":5}],"e":[]}`, nil},
+		{deleteAt("b", "-2", "c"), `{"a":[1,2,3],"b":[{},{"c":5}],"e":[]}`, nil},
+		{deleteAt("a", "-4"), ``, ErrIndexOutOfRange},
+		{deleteAt("e", "-1"), ``, ErrIndexOutOfRange},
+
+		{insertAt(-1, `9`, "a"), `{"a":[1,2,9,3],"b":[{"c":4},{"c":5}],"e":[]}`, nil},
+		{insertAt(-3, `9`, "a"), `{"a":[9,1,2,3],"b":[{"c":4},{"c":5}],"e":[]}`, nil},
+		{insertAt(-4, `9`, "a"), ``, ErrIndexOutOfRange},
+		{insertIntAt(-2, 9, "a"), `{"a":[1,9,2,3],"b":[{"c":4},{"c":5}],"e":[]}`, nil},
+		{insertIntAt(-1, 9, "a"), `{"a":[1,2,9,3],"b":[{"c":4},{"c":5}],"e":[]}`, nil},
+
+		{addAt(`9`, "a", "-"), `{"a":[1,2,3,9],"b":[{"c":4},{"c":5}],"e":[]}`, nil},
+		{addAt(`9`, "e", "-"), `{"a":[1,2,3],"b":[{"c":4},{"c":5}],"e":[9]}`, nil},
+		{insertAt(0, `9`, "a", "-"), `{"a":[1,2,3,9],"b":[{"c":4},{"c":5}],"e":[]}`, nil},
+		{insertAt(-7, `9`, "e", "-"), `{"a":[1,2,3],"b":[{"c":4},{"c":5}],"e":[9]}`, nil},
+	}
+	for _, test := range tests {
+		got, err := test.op.run([]byte(json))
+		pars, perr := Parse([]byte(json))
+		if perr != nil {
+			t.Fatal(perr)
+		}
+		parsGot, parsErr := test.op.apply(pars)
+		if test.err != nil {
+			if !errors.Is(err, test.err) || !errors.Is(parsErr, test.err) {
+				t.Errorf("%v: got %v and %v, want %v", test.op.name, err, parsErr, test.err)
+			}
+			continue
+		}
+		if err != nil || string(got) != test.want {
+			t.Errorf("%v: got %s %v, want %s", test.op.name, got, err, test.want)
+		}
+		if parsErr != nil || string(parsGot) != test.want {
+			t.Errorf("Parser.%v: got %s %v, want %s", test.op.name, parsGot, parsErr, test.want)
+		}
+	}
+}
+
+func TestLastIndexStart(t *testing.T) {
+	json := []byte(`[ 1 , [2, 3] ,{"x":"]"} ]`)
+	tests := []struct {
+		n     int
+		start int
+		err   error
+	}{
+		{1, 14, nil},
+		{2, 6, nil},
+		{3, 2, nil},
+		{4, -1, ErrIndexOutOfRange},
+	}
+	for _, test := range tests {
+		start, err := lastIndexStart(json, 0, test.n)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%v: got error %v, want %v", test.n, err, test.err)
+			continue
+		}
+		if start != test.start {
+			t.Errorf("%v: got start %v, want %v", test.n, start, test.start)
+		}
+	}
+	if _, err := lastIndexStart([]byte(` [ ] `), 1, 1); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("empty array returned %v, want ErrIndexOutOfRange", err)
+	}
+	if _, err := lastIndexStart([]byte(`[1,2`), 0, 1); !errors.Is(err, ErrBadJSON) {
+		t.Errorf("unterminated array returned %v, want ErrBadJSON", err)
+	}
+}
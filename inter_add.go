@@ -1,6 +1,11 @@
 package jin
 
-import "strconv"
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"time"
+)
 
 // AddKeyValue adds a key-value pair to an object.
 // Path variable must point to an object,
@@ -10,7 +15,7 @@ func AddKeyValue(json []byte, key string, value []byte, path ...string) ([]byte,
 	var end int
 	var err error
 	if len(json) < 2 {
-		return json, badJSONError(0)
+		return json, badJSONError(json, len(json), "value")
 	}
 	if len(path) == 0 {
 		for i := 0; i < len(json); i++ {
@@ -18,7 +23,7 @@ func AddKeyValue(json []byte, key string, value []byte, path ...string) ([]byte,
 				if json[i] == 123 {
 					start = i
 					if i == len(json)-1 {
-						return json, badJSONError(i)
+						return json, badJSONError(json, i+1, "'}'")
 					}
 					break
 				} else {
@@ -31,7 +36,7 @@ func AddKeyValue(json []byte, key string, value []byte, path ...string) ([]byte,
 				if json[i] == 125 {
 					end = i + 1
 					if i == 0 {
-						return json, badJSONError(i)
+						return json, badJSONError(json, i, "'{'")
 					}
 					break
 				} else {
@@ -53,15 +58,15 @@ func AddKeyValue(json []byte, key string, value []byte, path ...string) ([]byte,
 			}
 		}
 		if empty {
-			val := []byte(`"` + key + `":` + string(value))
+			val := []byte(`"` + escape(key) + `":` + string(value))
 			json = replace(json, val, end-1, end-1)
 			return json, nil
 		}
 		path = append(path, key)
 		_, _, _, err = core(json, false, path...)
 		if err != nil {
-			if err.Error() == keyNotFoundError().Error() {
-				val := []byte(`,"` + key + `":` + string(value))
+			if errors.Is(err, ErrKeyNotFound) {
+				val := []byte(`,"` + escape(key) + `":` + string(value))
 				json = replace(json, val, end-1, end-1)
 				return json, nil
 			}
@@ -75,12 +80,16 @@ func AddKeyValue(json []byte, key string, value []byte, path ...string) ([]byte,
 // Add adds a value to an array.
 // Path variable must point to an array,
 // otherwise it will provide an error message.
+// Last path can be "-" for pointing append position of the array, like JSON Patch.
 func Add(json []byte, value []byte, path ...string) ([]byte, error) {
 	var start int
 	var end int
 	var err error
+	if len(path) != 0 && path[len(path)-1] == "-" {
+		path = path[:len(path)-1]
+	}
 	if len(json) < 2 {
-		return json, badJSONError(0)
+		return json, badJSONError(json, len(json), "value")
 	}
 	if len(path) == 0 {
 		for i := 0; i < len(json); i++ {
@@ -88,7 +97,7 @@ func Add(json []byte, value []byte, path ...string) ([]byte, error) {
 				if json[i] == 91 {
 					start = i
 					if i == len(json)-1 {
-						return json, badJSONError(i)
+						return json, badJSONError(json, i+1, "']'")
 					}
 					break
 				} else {
@@ -101,7 +110,7 @@ func Add(json []byte, value []byte, path ...string) ([]byte, error) {
 				if json[i] == 93 {
 					end = i + 1
 					if i == 0 {
-						return json, badJSONError(i)
+						return json, badJSONError(json, i, "'['")
 					}
 					break
 				} else {
@@ -138,17 +147,23 @@ func Add(json []byte, value []byte, path ...string) ([]byte, error) {
 // Insert inserts a value to an array.
 // Path variable must point to an array,
 // otherwise it will provide an error message.
+// Negative index counts from the end of the array, -1 inserts before the last element.
+// Last path can be "-" for pointing append position of the array, like JSON Patch.
+// In that case value appended to the array and index is not used.
 func Insert(json []byte, index int, value []byte, path ...string) ([]byte, error) {
 	var start int
 	var end int
 	var err error
+	if len(path) != 0 && path[len(path)-1] == "-" {
+		return Add(json, value, path[:len(path)-1]...)
+	}
 	if len(path) == 0 {
 		for i := 0; i < len(json); i++ {
 			if !space(json[i]) {
 				if json[i] == 91 {
 					start = i
 					if i == len(json)-1 {
-						return json, badJSONError(i)
+						return json, badJSONError(json, i+1, "']'")
 					}
 					break
 				} else {
@@ -161,7 +176,7 @@ func Insert(json []byte, index int, value []byte, path ...string) ([]byte, error
 				if json[i] == 93 {
 					end = i + 1
 					if i == 0 {
-						return json, badJSONError(i)
+						return json, badJSONError(json, i, "'['")
 					}
 					break
 				} else {
@@ -220,14 +235,15 @@ func Insert(json []byte, index int, value []byte, path ...string) ([]byte, error
 		val := make([]byte, 0, len(value)+1)
 		val = append(val, 44)
 		val = append(val, value...)
-		json = replace(json, val, start-1, start-1)
+		json = replace(json, val, startEdge, startEdge)
 		return json, nil
 	}
-	return nil, badJSONError(start)
+	return nil, badJSONError(json, start, "',' or ']'")
 }
 
 // AddKeyValueString is a variation of AddKeyValue() func.
 // Type of new value must be a string.
+// Value is always written as a JSON string escaped like Quote() does, so "42" is a string, not a number.
 func AddKeyValueString(json []byte, key, value string, path ...string) ([]byte, error) {
 	if len(value) == 0 {
 		return nil, nullNewValueError()
@@ -235,7 +251,7 @@ func AddKeyValueString(json []byte, key, value string, path ...string) ([]byte,
 	if len(key) == 0 {
 		return nil, nullKeyError()
 	}
-	return AddKeyValue(json, key, []byte(formatType(value)), path...)
+	return AddKeyValue(json, key, []byte(Quote(value)), path...)
 }
 
 // AddKeyValueInt is a variation of AddKeyValue() func.
@@ -253,7 +269,11 @@ func AddKeyValueFloat(json []byte, key string, value float64, path ...string) ([
 	if len(key) == 0 {
 		return nil, nullKeyError()
 	}
-	return AddKeyValue(json, key, []byte(strconv.FormatFloat(value, 'e', -1, 64)), path...)
+	num, err := FormatFloat(value, -1)
+	if err != nil {
+		return json, err
+	}
+	return AddKeyValue(json, key, []byte(num), path...)
 }
 
 // AddKeyValueBool is a variation of AddKeyValue() func.
@@ -268,13 +288,41 @@ func AddKeyValueBool(json []byte, key string, value bool, path ...string) ([]byt
 	return AddKeyValue(json, key, []byte("false"), path...)
 }
 
+// AddKeyValueNull is a variation of AddKeyValue() func.
+// New value is null.
+func AddKeyValueNull(json []byte, key string, path ...string) ([]byte, error) {
+	if len(key) == 0 {
+		return nil, nullKeyError()
+	}
+	return AddKeyValue(json, key, []byte("null"), path...)
+}
+
+// AddKeyValueBytes is a variation of AddKeyValue() func.
+// New value is written as a base64 string like SetBytes() does.
+func AddKeyValueBytes(json []byte, key string, value []byte, enc *base64.Encoding, path ...string) ([]byte, error) {
+	if len(key) == 0 {
+		return nil, nullKeyError()
+	}
+	return AddKeyValue(json, key, encodeBase64(value, enc), path...)
+}
+
+// AddKeyValueTime is a variation of AddKeyValue() func.
+// New value is written with layout like SetTime() does.
+func AddKeyValueTime(json []byte, key string, value time.Time, layout string, path ...string) ([]byte, error) {
+	if len(key) == 0 {
+		return nil, nullKeyError()
+	}
+	return AddKeyValue(json, key, formatTime(value, layout), path...)
+}
+
 // AddString is a variation of Add() func.
 // Type of new value must be an string.
+// Value is always written as a JSON string escaped like Quote() does, so "42" is a string, not a number.
 func AddString(json []byte, value string, path ...string) ([]byte, error) {
 	if len(value) == 0 {
 		return nil, nullNewValueError()
 	}
-	return Add(json, []byte(formatType(value)), path...)
+	return Add(json, []byte(Quote(value)), path...)
 }
 
 // AddInt is a variation of Add() func.
@@ -286,7 +334,11 @@ func AddInt(json []byte, value int, path ...string) ([]byte, error) {
 // AddFloat is a variation of Add() func.
 // Type of new value must be an float64.
 func AddFloat(json []byte, value float64, path ...string) ([]byte, error) {
-	return Add(json, []byte(strconv.FormatFloat(value, 'e', -1, 64)), path...)
+	num, err := FormatFloat(value, -1)
+	if err != nil {
+		return json, err
+	}
+	return Add(json, []byte(num), path...)
 }
 
 // AddBool is a variation of Add() func.
@@ -298,44 +350,49 @@ func AddBool(json []byte, value bool, path ...string) ([]byte, error) {
 	return Add(json, []byte("false"), path...)
 }
 
+// AddNull is a variation of Add() func.
+// New value is null.
+func AddNull(json []byte, path ...string) ([]byte, error) {
+	return Add(json, []byte("null"), path...)
+}
+
 // InsertString is a variation of Insert() func.
 // Type of new value must be an string.
+// Value is always written as a JSON string escaped like Quote() does, so "42" is a string, not a number.
 func InsertString(json []byte, index int, value string, path ...string) ([]byte, error) {
 	if len(value) == 0 {
 		return nil, nullNewValueError()
 	}
-	if index < 0 {
-		return nil, indexOutOfRangeError()
-	}
-	return Insert(json, index, []byte(formatType(value)), path...)
+	return Insert(json, index, []byte(Quote(value)), path...)
 }
 
 // InsertInt is a variation of Insert() func.
 // Type of new value must be an integer.
 func InsertInt(json []byte, index, value int, path ...string) ([]byte, error) {
-	if index < 0 {
-		return nil, indexOutOfRangeError()
-	}
 	return Insert(json, index, []byte(strconv.Itoa(value)), path...)
 }
 
 // InsertFloat is a variation of Insert() func.
 // Type of new value must be an float64.
 func InsertFloat(json []byte, index int, value float64, path ...string) ([]byte, error) {
-	if index < 0 {
-		return nil, indexOutOfRangeError()
+	num, err := FormatFloat(value, -1)
+	if err != nil {
+		return json, err
 	}
-	return Insert(json, index, []byte(strconv.FormatFloat(value, 'e', -1, 64)), path...)
+	return Insert(json, index, []byte(num), path...)
 }
 
 // InsertBool is a variation of Insert() func.
 // Type of new value must be an boolean.
 func InsertBool(json []byte, index int, value bool, path ...string) ([]byte, error) {
-	if index < 0 {
-		return nil, indexOutOfRangeError()
-	}
 	if value {
 		return Insert(json, index, []byte("true"), path...)
 	}
 	return Insert(json, index, []byte("false"), path...)
 }
+
+// InsertNull is a variation of Insert() func.
+// New value is null.
+func InsertNull(json []byte, index int, path ...string) ([]byte, error) {
+	return Insert(json, index, []byte("null"), path...)
+}
@@ -8,7 +8,7 @@ import "strconv"
 func core(json []byte, justStart bool, path ...string) (int, int, int, error) {
 	// null json control.
 	if len(json) < 2 {
-		return -1, -1, -1, badJSONError(0)
+		return -1, -1, -1, badJSONError(json, len(json), "value")
 	}
 	// main offset track of this search.
 	offset := 0
@@ -29,13 +29,10 @@ func core(json []byte, justStart bool, path ...string) (int, int, int, error) {
 		isJSONChar[v] = true
 	}
 	// trim spaces of start
-	for space(json[offset]) {
-		// json length overflow control
-		if offset > len(json)-1 {
-			return -1, -1, -1, badJSONError(offset)
-		}
-		offset++
-		continue
+	offset = skipSpace(json, offset)
+	// json length overflow control
+	if offset == len(json) {
+		return -1, -1, -1, badJSONError(json, offset, "value")
 	}
 	// braceType determine whether or not search will be a key search or index search
 	braceType := json[offset]
@@ -50,7 +47,27 @@ func core(json []byte, justStart bool, path ...string) (int, int, int, error) {
 			arrayIndex, err := strconv.Atoi(currentPath)
 			if err != nil {
 				// braceType and current path type is conflicts.
-				return -1, -1, -1, indexExpectedError()
+				return -1, -1, -1, indexExpectedError().at(offset, currentPath)
+			}
+			// negative index search, counts from the end of the array.
+			if arrayIndex < 0 {
+				start, err := lastIndexStart(json, offset, -arrayIndex)
+				if err != nil {
+					if e, ok := err.(*Error); ok {
+						e.Segment = currentPath
+					}
+					return -1, -1, -1, err
+				}
+				offset = start
+				if k != len(path)-1 {
+					// next path needs a brace.
+					if json[start] != 91 && json[start] != 123 {
+						return -1, -1, -1, indexOutOfRangeError().at(start, currentPath)
+					}
+					braceType = json[start]
+					currentPath = path[k+1]
+				}
+				continue
 			}
 			// zeroth index search.
 			if arrayIndex == 0 {
@@ -74,7 +91,7 @@ func core(json []byte, justStart bool, path ...string) (int, int, int, error) {
 							break
 						} else {
 							if k != len(path)-1 {
-								return -1, -1, -1, indexOutOfRangeError()
+								return -1, -1, -1, indexOutOfRangeError().at(i, currentPath)
 							}
 							break
 						}
@@ -133,7 +150,7 @@ func core(json []byte, justStart bool, path ...string) (int, int, int, error) {
 											if !space(curr) {
 												if curr != 91 && curr != 123 {
 													if k != len(path)-1 {
-														return -1, -1, -1, indexOutOfRangeError()
+														return -1, -1, -1, indexOutOfRangeError().at(j, currentPath)
 													}
 													break
 												}
@@ -170,7 +187,7 @@ func core(json []byte, justStart bool, path ...string) (int, int, int, error) {
 						if curr == 93 || curr == 125 {
 							// if level is less than 1 it mean index not in this array.
 							if level < 2 {
-								return -1, -1, -1, indexOutOfRangeError()
+								return -1, -1, -1, indexOutOfRangeError().at(i, currentPath)
 							}
 							level--
 							continue
@@ -178,7 +195,7 @@ func core(json []byte, justStart bool, path ...string) (int, int, int, error) {
 					}
 				}
 				if !found {
-					return -1, -1, -1, indexOutOfRangeError()
+					return -1, -1, -1, indexOutOfRangeError().at(offset, currentPath)
 				}
 				// Check true for column char again for keep same with first declaration.
 				isJSONChar[58] = true
@@ -244,29 +261,16 @@ func core(json []byte, justStart bool, path ...string) (int, int, int, error) {
 						// column
 						if curr == 58 {
 							// comp between current path and key
-							// length comp between current path and key
-							if len(currentPath) == end-start {
-								same := true
-								// compare all elements
-								for j := 0; j < len(currentPath); j++ {
-									if currentPath[j] != json[start+j] {
-										same = false
-										break
-									} else {
-										continue
-									}
-								}
-								if same {
-									offset = i + 1
-									found = true
-									// if it is the last path element break
-									// and include comma character to json chars.
-									if k == len(path)-1 {
-										keyStart = start
-										break
-									} else {
-										continue
-									}
+							if keyMatch(byteArrayToString(json[start:end]), currentPath) {
+								offset = i + 1
+								found = true
+								// if it is the last path element break
+								// and include comma character to json chars.
+								if k == len(path)-1 {
+									keyStart = start
+									break
+								} else {
+									continue
 								}
 							}
 							// Include comma character to json chars for jump function
@@ -314,7 +318,7 @@ func core(json []byte, justStart bool, path ...string) (int, int, int, error) {
 									}
 									if curr == 93 || curr == 125 {
 										if level < k+1 {
-											return -1, -1, -1, keyNotFoundError()
+											return -1, -1, -1, keyNotFoundError().at(j, currentPath)
 										}
 										level--
 										continue
@@ -376,7 +380,7 @@ func core(json []byte, justStart bool, path ...string) (int, int, int, error) {
 					// Close brace
 					if curr == 93 || curr == 125 {
 						if level < k+1 {
-							return -1, -1, -1, keyNotFoundError()
+							return -1, -1, -1, keyNotFoundError().at(i, currentPath)
 						}
 						level--
 						continue
@@ -385,7 +389,7 @@ func core(json []byte, justStart bool, path ...string) (int, int, int, error) {
 			}
 			// key not found return error
 			if !found {
-				return -1, -1, -1, keyNotFoundError()
+				return -1, -1, -1, keyNotFoundError().at(offset, currentPath)
 			}
 			// Include comma character to json chars to restore original.
 			isJSONChar[44] = true
@@ -395,16 +399,13 @@ func core(json []byte, justStart bool, path ...string) (int, int, int, error) {
 	// this means not search operation has take place
 	// it must be some kinda error or bad format
 	if offset == 0 {
-		return -1, -1, -1, badJSONError(0)
+		return -1, -1, -1, badJSONError(json, skipSpace(json, 0), "'{' or '['")
 	}
 	// skip spaces from top.
-	for space(json[offset]) {
-		// json length overflow control
-		if offset > len(json)-1 {
-			return -1, -1, -1, badJSONError(offset)
-		}
-		offset++
-		continue
+	offset = skipSpace(json, offset)
+	// json length overflow control, value is missing.
+	if offset == len(json) {
+		return -1, -1, -1, badJSONError(json, offset, "value")
 	}
 	if justStart {
 		return keyStart, offset, 0, nil
@@ -478,7 +479,7 @@ func core(json []byte, justStart bool, path ...string) (int, int, int, error) {
 				// if current byte is space or one of these ',' ']' '}' this means end of the value is i
 				if space(curr) || curr == 44 || curr == 93 || curr == 125 {
 					if offset == i {
-						return -1, -1, -1, emptyArrayError()
+						return -1, -1, -1, emptyArrayError().at(offset, "")
 					}
 					return keyStart, offset, i, nil
 				}
@@ -488,5 +489,43 @@ func core(json []byte, justStart bool, path ...string) (int, int, int, error) {
 	// This means not search operation has take place
 	// not any formatting operation has take place
 	// it must be some kinda bad JSON format
-	return -1, -1, -1, badJSONError(offset)
+	return -1, -1, -1, badJSONError(json, offset, "value")
+}
+
+// lastIndexStart returns the start offset of the n'th element from the end of
+// the array that starts at json[offset]. It reads the array only once,
+// it keeps last n element starts in a ring.
+func lastIndexStart(json []byte, offset int, n int) (int, error) {
+	ring := make([]int, n)
+	count := 0
+	i := skipSpace(json, offset+1)
+	if i < len(json) && json[i] == 93 {
+		return -1, indexOutOfRangeError().at(i, "")
+	}
+	for i < len(json) {
+		ring[count%n] = i
+		count++
+		end, err := valueEnd(json, i)
+		if err != nil {
+			return -1, err
+		}
+		i = skipSpace(json, end)
+		if i >= len(json) {
+			break
+		}
+		// 44 = ,
+		if json[i] == 44 {
+			i = skipSpace(json, i+1)
+			continue
+		}
+		// 93 = ]
+		if json[i] == 93 {
+			if count < n {
+				return -1, indexOutOfRangeError().at(i, "")
+			}
+			return ring[count%n], nil
+		}
+		break
+	}
+	return -1, badJSONError(json, i, "',' or ']'")
 }
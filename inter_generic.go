@@ -0,0 +1,170 @@
+package jin
+
+import "reflect"
+
+// GetAs returns the value that path has pointed as T.
+// T can be any type that Unmarshal() takes, like scalars, slices, maps and structs,
+// so new types do not need new getter functions.
+//
+//	id, err := jin.GetAs[int64](json, "user", "id")
+//	tags, err := jin.GetAs[[]string](json, "user", "tags")
+//	user, err := jin.GetAs[User](json, "user")
+//
+// GetAs of string, int, int64, uint64, float64 and bool is same with GetString(), GetInt(), GetInt64(),
+// GetUint64(), GetFloat() and GetBool(), values and errors are exactly what they return.
+// Other types follow the same rules, null is an error for types that can not be nil, like GetInt() returns for it.
+// Values that overflow T return an error with code ErrNumberOverflow.
+func GetAs[T any](json []byte, path ...string) (T, error) {
+	var value T
+	if ok, err := getTyped(interpreter(json), &value, path); ok {
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+		return value, nil
+	}
+	start := skipSpace(json, 0)
+	if len(path) != 0 {
+		var err error
+		_, start, _, err = core(json, true, path...)
+		if err != nil {
+			return value, err
+		}
+	}
+	if err := decodeAt(json, start, &value); err != nil {
+		var zero T
+		return zero, err
+	}
+	return value, nil
+}
+
+// GetAsOr is a variation of GetAs() func.
+// Like GetIntOr() it returns def if path does not exist, value is null or there is an error.
+// Use GetAsOrHandler() for errors other than missing values.
+//
+//	retry := jin.GetAsOr(config, RetryPolicy{Max: 3}, "retry")
+func GetAsOr[T any](json []byte, def T, path ...string) T {
+	return GetAsOrHandler(json, def, nil, path...)
+}
+
+// GetAsOrHandler is a variation of GetAsOr() func, errors other than missing values are given to h.
+func GetAsOrHandler[T any](json []byte, def T, h ErrorHandler, path ...string) T {
+	start := skipSpace(json, 0)
+	if len(path) != 0 {
+		var err error
+		_, start, _, err = core(json, true, path...)
+		if err != nil {
+			h.handle(err, path)
+			return def
+		}
+	}
+	if isNull(json[start:]) {
+		return def
+	}
+	var value T
+	if err := decodeAt(json, start, &value); err != nil {
+		h.handle(err, path)
+		return def
+	}
+	return value
+}
+
+// SetAs is a variation of Set() func.
+// New value is written like Marshal() does, so it can be any type.
+// Unlike SetString(), strings are always written as strings.
+func SetAs[T any](json []byte, newValue T, path ...string) ([]byte, error) {
+	val, err := Marshal(newValue)
+	if err != nil {
+		return nil, err
+	}
+	return Set(json, val, path...)
+}
+
+// AddAs is a variation of Add() func.
+// New value is written like Marshal() does, so it can be any type.
+func AddAs[T any](json []byte, value T, path ...string) ([]byte, error) {
+	val, err := Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return Add(json, val, path...)
+}
+
+// AddKeyValueAs is a variation of AddKeyValue() func.
+// New value is written like Marshal() does, so it can be any type.
+func AddKeyValueAs[T any](json []byte, key string, value T, path ...string) ([]byte, error) {
+	val, err := Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return AddKeyValue(json, key, val, path...)
+}
+
+// typedGetter is the set of typed getters that both interpreter and Parser have.
+type typedGetter interface {
+	GetString(path ...string) (string, error)
+	GetInt(path ...string) (int, error)
+	GetInt64(path ...string) (int64, error)
+	GetUint64(path ...string) (uint64, error)
+	GetFloat(path ...string) (float64, error)
+	GetBool(path ...string) (bool, error)
+}
+
+// interpreter is the typedGetter of interpreter functions.
+type interpreter []byte
+
+func (json interpreter) GetString(path ...string) (string, error) { return GetString(json, path...) }
+func (json interpreter) GetInt(path ...string) (int, error)       { return GetInt(json, path...) }
+func (json interpreter) GetInt64(path ...string) (int64, error)   { return GetInt64(json, path...) }
+func (json interpreter) GetUint64(path ...string) (uint64, error) { return GetUint64(json, path...) }
+func (json interpreter) GetFloat(path ...string) (float64, error) { return GetFloat(json, path...) }
+func (json interpreter) GetBool(path ...string) (bool, error)     { return GetBool(json, path...) }
+
+// getTyped gets the value that path has pointed to target with the typed getter of its type,
+// so GetAs() of these types is exactly same with their typed getters. ok is false for other types.
+func getTyped(g typedGetter, target interface{}, path []string) (bool, error) {
+	var err error
+	switch t := target.(type) {
+	case *string:
+		*t, err = g.GetString(path...)
+	case *int:
+		*t, err = g.GetInt(path...)
+	case *int64:
+		*t, err = g.GetInt64(path...)
+	case *uint64:
+		*t, err = g.GetUint64(path...)
+	case *float64:
+		*t, err = g.GetFloat(path...)
+	case *bool:
+		*t, err = g.GetBool(path...)
+	default:
+		return false, nil
+	}
+	return true, err
+}
+
+// decodeAt decodes the value that starts at json[start] to the value that target points to,
+// offsets of errors are offsets in json.
+// Unlike Unmarshal(), null is not ignored for types that can not be nil, it is converted like typed getters do,
+// so strings are "null" like GetString() returns and numbers or booleans return a parse error.
+func decodeAt(json []byte, start int, target interface{}) error {
+	v := reflect.ValueOf(target).Elem()
+	if start < len(json) && isNull(json[start:]) && !nillable(v) {
+		if err := setScalar(v, KindNull, "null"); err != nil {
+			return err.at(start, "")
+		}
+		return nil
+	}
+	d := decoder{json: json}
+	_, err := d.value(start, v, "")
+	return err
+}
+
+// nillable reports whether null is a valid value for v, types that implement Unmarshaler decide it themselves.
+func nillable(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+		return true
+	}
+	return v.Addr().Type().Implements(unmarshalerType)
+}
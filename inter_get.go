@@ -1,7 +1,9 @@
 package jin
 
 import (
+	"math/big"
 	"strconv"
+	"time"
 )
 
 // Get returns the value that path has pointed.
@@ -22,7 +24,27 @@ func Get(json []byte, path ...string) ([]byte, error) {
 
 // GetString is a variation of Get() func.
 // GetString returns the value that path has pointed as string.
+// Escape sequences of string values are decoded, like '\n', '\"' and '\u00e9'.
+// Use GetStringRaw() for the value as it is in JSON.
 func GetString(json []byte, path ...string) (string, error) {
+	if len(path) == 0 {
+		return string(json), nil
+	}
+	_, start, end, err := core(json, false, path...)
+	if err != nil {
+		return "", err
+	}
+	// core() returns strings without quotation marks.
+	if start > 0 && json[start-1] == 34 && end < len(json) && json[end] == 34 {
+		return string(unescape(json[start:end])), nil
+	}
+	return string(json[start:end]), nil
+}
+
+// GetStringRaw is a variation of Get() func.
+// GetStringRaw returns the value that path has pointed as string,
+// escape sequences are not decoded.
+func GetStringRaw(json []byte, path ...string) (string, error) {
 	val, err := Get(json, path...)
 	if err != nil {
 		return "", err
@@ -40,7 +62,7 @@ func GetInt(json []byte, path ...string) (int, error) {
 	}
 	intVal, err := strconv.Atoi(val)
 	if err != nil {
-		return -1, intParseError(val)
+		return -1, rangeError(err, val, "int", intParseError(val))
 	}
 	return intVal, nil
 }
@@ -55,7 +77,7 @@ func GetFloat(json []byte, path ...string) (float64, error) {
 	}
 	floatVal, err := strconv.ParseFloat(val, 64)
 	if err != nil {
-		return -1, floatParseError(val)
+		return -1, rangeError(err, val, "float64", floatParseError(val))
 	}
 	return floatVal, nil
 }
@@ -77,6 +99,218 @@ func GetBool(json []byte, path ...string) (bool, error) {
 	return false, boolParseError(val)
 }
 
+// GetNumber is a variation of Get() func.
+// GetNumber returns the value that path has pointed as Number, in its exact lexical form.
+// returns an error message if the value to be returned is not a number.
+func GetNumber(json []byte, path ...string) (Number, error) {
+	val, err := GetString(json, path...)
+	if err != nil {
+		return "", err
+	}
+	return toNumber(val)
+}
+
+// GetInt64 is a variation of Get() func.
+// GetInt64 returns the value that path has pointed as int64.
+// returns an error message if the value to be returned cannot be converted to an int64,
+// error code is ErrNumberOverflow if the value is out of int64 range.
+func GetInt64(json []byte, path ...string) (int64, error) {
+	val, err := GetString(json, path...)
+	if err != nil {
+		return -1, err
+	}
+	return Number(val).Int64()
+}
+
+// GetUint64 is a variation of Get() func.
+// GetUint64 returns the value that path has pointed as uint64.
+// returns an error message if the value to be returned cannot be converted to an uint64,
+// error code is ErrNumberOverflow if the value is out of uint64 range.
+func GetUint64(json []byte, path ...string) (uint64, error) {
+	val, err := GetString(json, path...)
+	if err != nil {
+		return 0, err
+	}
+	return Number(val).Uint64()
+}
+
+// GetBigInt is a variation of Get() func.
+// GetBigInt returns the value that path has pointed as *big.Int, it never overflows.
+// returns an error message if the value to be returned cannot be converted to an integer.
+func GetBigInt(json []byte, path ...string) (*big.Int, error) {
+	val, err := GetString(json, path...)
+	if err != nil {
+		return nil, err
+	}
+	return Number(val).BigInt()
+}
+
+// GetBigFloat is a variation of Get() func.
+// GetBigFloat returns the value that path has pointed as *big.Float,
+// precision is enough to hold all decimal digits of the value.
+// returns an error message if the value to be returned cannot be converted to a float.
+func GetBigFloat(json []byte, path ...string) (*big.Float, error) {
+	val, err := GetString(json, path...)
+	if err != nil {
+		return nil, err
+	}
+	return Number(val).BigFloat()
+}
+
+// ErrorHandler receives the errors of GetStringOr(), GetIntOr() and other default value getters,
+// when they return the default value because of an error that is not a missing value,
+// like a malformed JSON or a value that cannot be converted.
+// Missing keys, out of range indexes and null values return the default value silently.
+// Handlers are given per call with its methods or per Parser with SetErrorHandler(),
+// package level getters like GetIntOr() ignore errors.
+//
+//	logError := jin.ErrorHandler(func(err error, path []string) { log.Println(path, err) })
+//	timeout := logError.GetIntOr(config, 30, "server", "timeout")
+type ErrorHandler func(err error, path []string)
+
+// GetStringOr is a variation of GetString() func.
+// It returns def if path does not exist, value is null or there is an error.
+// Use ErrorHandler for errors other than missing values.
+func GetStringOr(json []byte, def string, path ...string) string {
+	return ErrorHandler(nil).GetStringOr(json, def, path...)
+}
+
+// GetIntOr is a variation of GetInt() func.
+// It returns def if path does not exist, value is null or there is an error.
+// Use ErrorHandler for errors other than missing values.
+//
+//	timeout := jin.GetIntOr(config, 30, "server", "timeout")
+func GetIntOr(json []byte, def int, path ...string) int {
+	return ErrorHandler(nil).GetIntOr(json, def, path...)
+}
+
+// GetFloatOr is a variation of GetFloat() func.
+// It returns def if path does not exist, value is null or there is an error.
+// Use ErrorHandler for errors other than missing values.
+func GetFloatOr(json []byte, def float64, path ...string) float64 {
+	return ErrorHandler(nil).GetFloatOr(json, def, path...)
+}
+
+// GetBoolOr is a variation of GetBool() func.
+// It returns def if path does not exist, value is null or there is an error.
+// Use ErrorHandler for errors other than missing values.
+func GetBoolOr(json []byte, def bool, path ...string) bool {
+	return ErrorHandler(nil).GetBoolOr(json, def, path...)
+}
+
+// GetStringOr is a variation of GetStringOr() func, errors other than missing values are given to h.
+func (h ErrorHandler) GetStringOr(json []byte, def string, path ...string) string {
+	val, err := GetString(json, path...)
+	if err != nil {
+		h.handle(err, path)
+		return def
+	}
+	if val == "null" {
+		if null, _ := IsNull(json, path...); null {
+			return def
+		}
+	}
+	return val
+}
+
+// GetIntOr is a variation of GetIntOr() func, errors other than missing values are given to h.
+func (h ErrorHandler) GetIntOr(json []byte, def int, path ...string) int {
+	val, err := GetInt(json, path...)
+	if err != nil {
+		h.orError(json, err, path)
+		return def
+	}
+	return val
+}
+
+// GetFloatOr is a variation of GetFloatOr() func, errors other than missing values are given to h.
+func (h ErrorHandler) GetFloatOr(json []byte, def float64, path ...string) float64 {
+	val, err := GetFloat(json, path...)
+	if err != nil {
+		h.orError(json, err, path)
+		return def
+	}
+	return val
+}
+
+// GetBoolOr is a variation of GetBoolOr() func, errors other than missing values are given to h.
+func (h ErrorHandler) GetBoolOr(json []byte, def bool, path ...string) bool {
+	val, err := GetBool(json, path...)
+	if err != nil {
+		h.orError(json, err, path)
+		return def
+	}
+	return val
+}
+
+// orError gives err to h, unless the value is null.
+func (h ErrorHandler) orError(json []byte, err error, path []string) {
+	if h == nil {
+		return
+	}
+	if null, _ := IsNull(json, path...); !null {
+		h.handle(err, path)
+	}
+}
+
+// handle gives err to h if it is set and err is not a missing value error.
+func (h ErrorHandler) handle(err error, path []string) {
+	if h != nil && !isMissing(err) {
+		h(err, path)
+	}
+}
+
+// isMissing reports whether err is about a value that does not exist,
+// like a missing key or an out of range index.
+func isMissing(err error) bool {
+	e, ok := err.(*Error)
+	if !ok {
+		return false
+	}
+	switch e.Code {
+	case ErrKeyNotFound.Code, ErrIndexOutOfRange.Code, ErrEmptyArray.Code, ErrEmpty.Code:
+		return true
+	}
+	return false
+}
+
+// GetTime is a variation of Get() func.
+// GetTime returns the value that path has pointed as time.Time, parsed with layout.
+// layout can be any layout of time.Parse(), empty layout is time.RFC3339Nano,
+// LayoutUnix and LayoutUnixMilli are for Unix timestamps given as numbers, they are returned in UTC.
+//
+//	created, err := jin.GetTime(json, time.RFC3339, "created_at")
+//	updated, err := jin.GetTime(json, jin.LayoutUnixMilli, "updated_at")
+func GetTime(json []byte, layout string, path ...string) (time.Time, error) {
+	val, err := GetString(json, path...)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return parseTime(val, layout)
+}
+
+// GetDuration is a variation of Get() func.
+// GetDuration returns the value that path has pointed as time.Duration,
+// value must be a Go duration string like "1h30m" or "250ms".
+func GetDuration(json []byte, path ...string) (time.Duration, error) {
+	val, err := GetString(json, path...)
+	if err != nil {
+		return 0, err
+	}
+	return parseDuration(val)
+}
+
+// GetBytes is a variation of Get() func.
+// GetBytes returns the value that path has pointed as binary data decoded from base64.
+// Standard and URL-safe alphabets are accepted, with or without padding.
+func GetBytes(json []byte, path ...string) ([]byte, error) {
+	val, err := GetString(json, path...)
+	if err != nil {
+		return nil, err
+	}
+	return decodeBase64(val)
+}
+
 // GetStringArray is a variation of Get() func.
 // GetStringArray returns the value that path has pointed as string slice.
 // returns an error message if the value to be returned cannot be converted to an string slice.
@@ -90,7 +324,7 @@ func GetStringArray(json []byte, path ...string) ([]string, error) {
 		return nil, stringArrayParseError(val)
 	}
 	if val[0] == 91 && val[lena-1] == 93 {
-		arr := ParseArray(val)
+		arr := parseArray(val, true)
 		if arr == nil {
 			return nil, stringArrayParseError(val)
 		}
@@ -139,7 +373,7 @@ func GetIntArray(json []byte, path ...string) ([]int, error) {
 							element := val[start:i]
 							num, err := strconv.Atoi(cleanValueString(element))
 							if err != nil {
-								return nil, intParseError(cleanValueString(element))
+								return nil, rangeError(err, cleanValueString(element), "int", intParseError(cleanValueString(element)))
 							}
 							newArray = append(newArray, num)
 							break
@@ -151,7 +385,7 @@ func GetIntArray(json []byte, path ...string) ([]int, error) {
 						element := val[start:i]
 						num, err := strconv.Atoi(cleanValueString(element))
 						if err != nil {
-							return nil, intParseError(cleanValueString(element))
+							return nil, rangeError(err, cleanValueString(element), "int", intParseError(cleanValueString(element)))
 						}
 						newArray = append(newArray, num)
 						start = i + 1
@@ -205,7 +439,7 @@ func GetFloatArray(json []byte, path ...string) ([]float64, error) {
 							element := val[start:i]
 							num, err := strconv.ParseFloat(cleanValueString(element), 64)
 							if err != nil {
-								return nil, floatParseError(cleanValueString(element))
+								return nil, rangeError(err, cleanValueString(element), "float64", floatParseError(cleanValueString(element)))
 							}
 							newArray = append(newArray, num)
 							break
@@ -217,7 +451,7 @@ func GetFloatArray(json []byte, path ...string) ([]float64, error) {
 						element := val[start:i]
 						num, err := strconv.ParseFloat(cleanValueString(element), 64)
 						if err != nil {
-							return nil, floatParseError(cleanValueString(element))
+							return nil, rangeError(err, cleanValueString(element), "float64", floatParseError(cleanValueString(element)))
 						}
 						newArray = append(newArray, num)
 						start = i + 1
@@ -307,8 +541,66 @@ func GetBoolArray(json []byte, path ...string) ([]bool, error) {
 	return nil, boolArrayParseError(val)
 }
 
+// GetNumberArray is a variation of Get() func.
+// GetNumberArray returns the value that path has pointed as Number slice.
+// returns an error message if the value to be returned cannot be converted to a Number slice.
+func GetNumberArray(json []byte, path ...string) ([]Number, error) {
+	val, err := GetString(json, path...)
+	if err != nil {
+		return nil, err
+	}
+	return numberArray(val, numberParseError)
+}
+
+// GetInt64Array is a variation of Get() func.
+// GetInt64Array returns the value that path has pointed as int64 slice.
+// returns an error message if the value to be returned cannot be converted to an int64 slice,
+// error code is ErrNumberOverflow if an element is out of int64 range.
+func GetInt64Array(json []byte, path ...string) ([]int64, error) {
+	val, err := GetString(json, path...)
+	if err != nil {
+		return nil, err
+	}
+	numbers, err := numberArray(val, intArrayParseError)
+	if err != nil {
+		return nil, err
+	}
+	newArray := make([]int64, len(numbers))
+	for i, n := range numbers {
+		newArray[i], err = n.Int64()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return newArray, nil
+}
+
+// GetUint64Array is a variation of Get() func.
+// GetUint64Array returns the value that path has pointed as uint64 slice.
+// returns an error message if the value to be returned cannot be converted to an uint64 slice,
+// error code is ErrNumberOverflow if an element is out of uint64 range.
+func GetUint64Array(json []byte, path ...string) ([]uint64, error) {
+	val, err := GetString(json, path...)
+	if err != nil {
+		return nil, err
+	}
+	numbers, err := numberArray(val, intArrayParseError)
+	if err != nil {
+		return nil, err
+	}
+	newArray := make([]uint64, len(numbers))
+	for i, n := range numbers {
+		newArray[i], err = n.Uint64()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return newArray, nil
+}
+
 // GetKeys not tested yet
 // Gets all keys that path has pointed.
+// Escape sequences of keys are decoded, decoded keys still can be used in paths.
 func GetKeys(json []byte, path ...string) ([]string, error) {
 	var keys []string
 	if string(json) == "{}" {
@@ -319,7 +611,7 @@ func GetKeys(json []byte, path ...string) ([]string, error) {
 	if len(path) == 0 {
 		for space(json[start]) {
 			if start > len(json)-1 {
-				return nil, badJSONError(start)
+				return nil, badJSONError(json, start, "value")
 			}
 			start++
 			continue
@@ -385,7 +677,7 @@ func GetKeys(json []byte, path ...string) ([]string, error) {
 				}
 				if curr == 58 {
 					if level == 1 {
-						key := json[keyStart+1 : keyEnd]
+						key := unescape(json[keyStart+1 : keyEnd])
 						keys = append(keys, string(key))
 					}
 					continue
@@ -399,6 +691,7 @@ func GetKeys(json []byte, path ...string) ([]string, error) {
 
 // GetValues not tested yet
 // Gets all values that path has pointed.
+// Escape sequences of string values are decoded.
 func GetValues(json []byte, path ...string) ([]string, error) {
 	var values []string
 	if string(json) == "{}" {
@@ -409,7 +702,7 @@ func GetValues(json []byte, path ...string) ([]string, error) {
 	if len(path) == 0 {
 		for space(json[start]) {
 			if start > len(json)-1 {
-				return nil, badJSONError(start)
+				return nil, badJSONError(json, start, "value")
 			}
 			start++
 			continue
@@ -461,7 +754,7 @@ func GetValues(json []byte, path ...string) ([]string, error) {
 				}
 				if curr == 93 || curr == 125 {
 					if level == 1 {
-						value := cleanValueString(string(json[start:i]))
+						value := string(cleanString(json[start:i]))
 						values = append(values, value)
 						start = i + 1
 						break
@@ -477,7 +770,7 @@ func GetValues(json []byte, path ...string) ([]string, error) {
 				}
 				if curr == 44 {
 					if level == 1 {
-						value := cleanValueString(string(json[start:i]))
+						value := string(cleanString(json[start:i]))
 						values = append(values, value)
 						start = i + 1
 					}
@@ -491,6 +784,7 @@ func GetValues(json []byte, path ...string) ([]string, error) {
 
 // GetKeysValues not tested yet
 // Gets all keys and values that path has pointed.
+// Escape sequences of keys and string values are decoded.
 func GetKeysValues(json []byte, path ...string) ([]string, []string, error) {
 	var values []string
 	var keys []string
@@ -502,7 +796,7 @@ func GetKeysValues(json []byte, path ...string) ([]string, []string, error) {
 	if len(path) == 0 {
 		for space(json[start]) {
 			if start > len(json)-1 {
-				return nil, nil, badJSONError(start)
+				return nil, nil, badJSONError(json, start, "value")
 			}
 			start++
 			continue
@@ -561,7 +855,7 @@ func GetKeysValues(json []byte, path ...string) ([]string, []string, error) {
 				}
 				if curr == 93 || curr == 125 {
 					if level == 1 {
-						value := cleanValueString(string(json[start:i]))
+						value := string(cleanString(json[start:i]))
 						values = append(values, value)
 						start = i + 1
 						break
@@ -571,7 +865,7 @@ func GetKeysValues(json []byte, path ...string) ([]string, []string, error) {
 				}
 				if curr == 58 {
 					if level == 1 {
-						key := json[keyStart+1 : keyEnd]
+						key := unescape(json[keyStart+1 : keyEnd])
 						keys = append(keys, string(key))
 						start = i + 1
 					}
@@ -579,7 +873,7 @@ func GetKeysValues(json []byte, path ...string) ([]string, []string, error) {
 				}
 				if curr == 44 {
 					if level == 1 {
-						value := cleanValueString(string(json[start:i]))
+						value := string(cleanString(json[start:i]))
 						values = append(values, value)
 						start = i + 1
 					}
@@ -624,7 +918,7 @@ func GetMap(json []byte, path ...string) (map[string]string, error) {
 		}
 		return mainMap, nil
 	}
-	return nil, badJSONError(start)
+	return nil, badJSONError(json, start, "'{' or '['")
 }
 
 // GetAll not tested yet
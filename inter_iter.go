@@ -1,7 +1,7 @@
 package jin
 
 // IterateArray is a callback function that can iterate any array and return value as byte slice.
-// It stripes quotation marks from string values befour return.
+// It stripes quotation marks and decodes escape sequences of string values befour return.
 // Path value can be left blank for access main JSON.
 func IterateArray(json []byte, callback func([]byte) bool, path ...string) error {
 	if string(json) == "[]" {
@@ -12,7 +12,7 @@ func IterateArray(json []byte, callback func([]byte) bool, path ...string) error
 	if len(path) == 0 {
 		for space(json[start]) {
 			if start > len(json)-2 {
-				return badJSONError(start)
+				return badJSONError(json, start, "value")
 			}
 			start++
 			continue
@@ -71,7 +71,7 @@ func IterateArray(json []byte, callback func([]byte) bool, path ...string) error
 				}
 				if curr == 93 {
 					if level == 0 {
-						callback(cleanValue(json[start:i]))
+						callback(cleanString(json[start:i]))
 						return nil
 					}
 				}
@@ -80,7 +80,7 @@ func IterateArray(json []byte, callback func([]byte) bool, path ...string) error
 			}
 			if level == 0 {
 				if curr == 44 {
-					if !callback(cleanValue(json[start:i])) {
+					if !callback(cleanString(json[start:i])) {
 						return nil
 					}
 					start = i + 1
@@ -89,11 +89,11 @@ func IterateArray(json []byte, callback func([]byte) bool, path ...string) error
 			}
 		}
 	}
-	return badJSONError(start)
+	return badJSONError(json, start, "']'")
 }
 
 // IterateKeyValue is a callback function that can iterate any object and return key-value pair as byte slices.
-// It stripes quotation marks from string values befour return.
+// It stripes quotation marks and decodes escape sequences of string values befour return.
 // Path value can be left blank for access main JSON.
 func IterateKeyValue(json []byte, callback func([]byte, []byte) bool, path ...string) error {
 	if string(json) == "{}" {
@@ -105,7 +105,7 @@ func IterateKeyValue(json []byte, callback func([]byte, []byte) bool, path ...st
 	if len(path) == 0 {
 		for space(json[start]) {
 			if start > len(json)-1 {
-				return badJSONError(start)
+				return badJSONError(json, start, "value")
 			}
 			start++
 			continue
@@ -179,7 +179,7 @@ func IterateKeyValue(json []byte, callback func([]byte, []byte) bool, path ...st
 								}
 							}
 							if json[start] == 34 && json[end] == 34 {
-								callback(key, json[start+1:end])
+								callback(key, unescape(json[start+1:end]))
 								return nil
 							}
 							callback(key, json[start:end+1])
@@ -208,7 +208,7 @@ func IterateKeyValue(json []byte, callback func([]byte, []byte) bool, path ...st
 							}
 						}
 						if json[start] == 34 && json[end] == 34 {
-							if !callback(key, json[start+1:end]) {
+							if !callback(key, unescape(json[start+1:end])) {
 								return nil
 							}
 						} else {
@@ -219,7 +219,7 @@ func IterateKeyValue(json []byte, callback func([]byte, []byte) bool, path ...st
 						continue
 					}
 					if curr == 58 {
-						key = json[keyStart+1 : keyEnd]
+						key = unescape(json[keyStart+1 : keyEnd])
 						start = i + 1
 						continue
 					}
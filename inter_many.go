@@ -0,0 +1,209 @@
+package jin
+
+import "strconv"
+
+// GetMany gets the values of paths with a single scan of json, paths that share a prefix are walked once.
+// values and errs are in the order of paths, values[i] and errs[i] are same as Get(json, paths[i]...) returns.
+//
+//	values, errs := jin.GetMany(json, [][]string{{"user", "name"}, {"user", "tags", "0"}, {"id"}})
+//
+// Use GetManyFunc() for not allocating result slices.
+func GetMany(json []byte, paths [][]string) ([][]byte, []error) {
+	values := make([][]byte, len(paths))
+	errs := make([]error, len(paths))
+	GetManyFunc(json, paths, func(index int, value []byte, err error) {
+		values[index] = value
+		errs[index] = err
+	})
+	return values, errs
+}
+
+// GetManyFunc is a variation of GetMany() func, it calls callback once for every path instead of returning slices.
+// index is the index of the path in paths, value and err are same as Get() returns for that path.
+// Callback is called in the order that values end in json, so nested values come before their containers,
+// not in the order of paths. Paths under a malformed part of json get its error.
+func GetManyFunc(json []byte, paths [][]string, callback func(index int, value []byte, err error)) {
+	root := &pathNode{}
+	for i, path := range paths {
+		root.add(path, i)
+	}
+	for _, i := range root.targets {
+		callback(i, json, nil)
+	}
+	root.resolve(json, skipSpace(json, 0), false, paths, callback)
+}
+
+// pathNode is a level of the path tree of GetManyFunc(), paths that share a prefix share nodes.
+type pathNode struct {
+	key string
+	// index is the key as an array index, isIndex is false for keys that are not integers.
+	index   int
+	isIndex bool
+	// targets are the indexes of the paths that end at this node.
+	targets  []int
+	children []*pathNode
+	found    bool
+}
+
+// add adds the path at index i of paths to the tree.
+func (n *pathNode) add(path []string, i int) {
+	for _, key := range path {
+		var child *pathNode
+		for _, c := range n.children {
+			if c.key == key {
+				child = c
+				break
+			}
+		}
+		if child == nil {
+			index, err := strconv.Atoi(key)
+			child = &pathNode{key: key, index: index, isIndex: err == nil}
+			n.children = append(n.children, child)
+		}
+		n = child
+	}
+	n.targets = append(n.targets, i)
+}
+
+// resolve finds the children of n in the value that starts at json[start] with a single pass.
+// Values of found children are not scanned again, nested children are resolved while the value is read.
+// Children that are not found, like missing keys or negative indexes, are resolved with Get()
+// so their values and errors are same as Get() returns, if json is malformed they get that error instead.
+// needEnd is true if the caller needs the end of the value, otherwise the scan stops at the last child,
+// resolve returns that end, it is -1 if the scan has stopped before.
+func (n *pathNode) resolve(json []byte, start int, needEnd bool, paths [][]string, callback func(index int, value []byte, err error)) (int, error) {
+	end := -1
+	var err error
+	// 91 = [, 123 = {
+	if len(n.children) > 0 && start < len(json) && (json[start] == 91 || json[start] == 123) {
+		end, err = n.scan(json, start, needEnd, paths, callback)
+	}
+	for _, c := range n.children {
+		if c.found {
+			continue
+		}
+		if err != nil {
+			c.fail(err, callback)
+		} else {
+			c.fallback(json, paths, callback)
+		}
+	}
+	return end, err
+}
+
+// scan reads the members of the object or the elements of the array that starts at json[start],
+// like eachChild() does, and resolves the children of n that it meets.
+func (n *pathNode) scan(json []byte, start int, needEnd bool, paths [][]string, callback func(index int, value []byte, err error)) (int, error) {
+	brace := json[start]
+	isObject := brace == 123
+	remaining := len(n.children)
+	index := 0
+	i := start + 1
+	for {
+		i = skipSpace(json, i)
+		if i >= len(json) {
+			return -1, badJSONError(json, i, "value")
+		}
+		// 93 = ], 125 = }
+		if json[i] == brace+2 && index == 0 {
+			return i + 1, nil
+		}
+		var label string
+		if isObject {
+			// 34 = "
+			if json[i] != 34 {
+				return -1, badJSONError(json, i, "'\"'")
+			}
+			keyEnd, err := valueEnd(json, i)
+			if err != nil {
+				return -1, err
+			}
+			label = string(json[i+1 : keyEnd-1])
+			i = skipSpace(json, keyEnd)
+			// 58 = :
+			if i >= len(json) || json[i] != 58 {
+				return -1, badJSONError(json, i, "':'")
+			}
+			i = skipSpace(json, i+1)
+		}
+		end := -1
+		for _, c := range n.children {
+			if c.found {
+				continue
+			}
+			if isObject && !keyMatch(label, c.key) || !isObject && (!c.isIndex || c.index != index) {
+				continue
+			}
+			c.found = true
+			remaining--
+			cEnd, err := c.resolve(json, i, end == -1 && (len(c.targets) > 0 || remaining > 0 || needEnd), paths, callback)
+			if err == nil && end == -1 && cEnd == -1 && len(c.targets) > 0 {
+				cEnd, err = valueEnd(json, i)
+			}
+			if err != nil {
+				for _, t := range c.targets {
+					callback(t, nil, err)
+				}
+				return -1, err
+			}
+			if cEnd != -1 {
+				end = cEnd
+			}
+			if len(c.targets) > 0 {
+				value := json[i:end]
+				// 34 = ", strings are returned without quotation marks like core() returns them.
+				if json[i] == 34 {
+					value = json[i+1 : end-1]
+				}
+				for _, t := range c.targets {
+					callback(t, value, nil)
+				}
+			}
+		}
+		if remaining == 0 && !needEnd {
+			return -1, nil
+		}
+		if end == -1 {
+			var err error
+			end, err = valueEnd(json, i)
+			if err != nil {
+				return -1, err
+			}
+		}
+		index++
+		i = skipSpace(json, end)
+		if i >= len(json) {
+			return -1, badJSONError(json, i, "',' or '"+string(brace+2)+"'")
+		}
+		// 44 = ,
+		if json[i] == 44 {
+			i++
+			continue
+		}
+		if json[i] == brace+2 {
+			return i + 1, nil
+		}
+		return -1, badJSONError(json, i, "',' or '"+string(brace+2)+"'")
+	}
+}
+
+// fail reports err for every path that ends at n or under n.
+func (n *pathNode) fail(err error, callback func(index int, value []byte, err error)) {
+	for _, i := range n.targets {
+		callback(i, nil, err)
+	}
+	for _, c := range n.children {
+		c.fail(err, callback)
+	}
+}
+
+// fallback resolves every path that ends at n or under n with Get().
+func (n *pathNode) fallback(json []byte, paths [][]string, callback func(index int, value []byte, err error)) {
+	for _, i := range n.targets {
+		value, err := Get(json, paths[i]...)
+		callback(i, value, err)
+	}
+	for _, c := range n.children {
+		c.fallback(json, paths, callback)
+	}
+}
@@ -1,6 +1,11 @@
 package jin
 
-import "strconv"
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"time"
+)
 
 // Set sets the value that path has pointed.
 // Path can point anything, a key-value pair, a value, an array, an object.
@@ -22,11 +27,12 @@ func Set(json []byte, newValue []byte, path ...string) ([]byte, error) {
 
 // SetString is a variation of Set() func.
 // SetString takes the set value as string.
+// Value is always written as a JSON string escaped like Quote() does, so "42" is a string, not a number.
 func SetString(json []byte, newValue string, path ...string) ([]byte, error) {
 	if len(newValue) == 0 {
 		return nil, nullNewValueError()
 	}
-	return Set(json, []byte(formatType(newValue)), path...)
+	return Set(json, []byte(Quote(newValue)), path...)
 }
 
 // SetInt is a variation of Set() func.
@@ -37,8 +43,14 @@ func SetInt(json []byte, newValue int, path ...string) ([]byte, error) {
 
 // SetFloat is a variation of Set() func.
 // SetFloat takes the set value as float64.
+// Value is written in the shortest form that reads back exactly, like 12.5 instead of 1.25e+01.
+// Use FormatFloat() with Set() for a fixed precision.
 func SetFloat(json []byte, newValue float64, path ...string) ([]byte, error) {
-	return Set(json, []byte(strconv.FormatFloat(newValue, 'e', -1, 64)), path...)
+	num, err := FormatFloat(newValue, -1)
+	if err != nil {
+		return json, err
+	}
+	return Set(json, []byte(num), path...)
 }
 
 // SetBool is a variation of Set() func.
@@ -50,6 +62,26 @@ func SetBool(json []byte, newValue bool, path ...string) ([]byte, error) {
 	return Set(json, []byte("false"), path...)
 }
 
+// SetNull is a variation of Set() func.
+// SetNull sets the value that path has pointed to null.
+func SetNull(json []byte, path ...string) ([]byte, error) {
+	return Set(json, []byte("null"), path...)
+}
+
+// SetTime is a variation of Set() func.
+// SetTime writes newValue with layout like GetTime() reads it,
+// Unix layouts are written as numbers, other layouts as strings.
+func SetTime(json []byte, newValue time.Time, layout string, path ...string) ([]byte, error) {
+	return Set(json, formatTime(newValue, layout), path...)
+}
+
+// SetBytes is a variation of Set() func.
+// SetBytes writes newValue as a base64 string encoded with enc,
+// like base64.URLEncoding or base64.RawStdEncoding. nil enc is base64.StdEncoding.
+func SetBytes(json []byte, newValue []byte, enc *base64.Encoding, path ...string) ([]byte, error) {
+	return Set(json, encodeBase64(newValue, enc), path...)
+}
+
 // SetKey sets the key value of key-value pair that path has pointed.
 // Path must point to an object.
 // otherwise it will provide an error message.
@@ -69,7 +101,7 @@ func SetKey(json []byte, newKey string, path ...string) ([]byte, error) {
 	newPath[len(newPath)-1] = newKey
 	_, _, _, err = core(json, false, newPath...)
 	if err != nil {
-		if err.Error() == keyNotFoundError().Error() {
+		if errors.Is(err, ErrKeyNotFound) {
 			keyStart, start, _, err = core(json, false, path...)
 			if err != nil {
 				return json, err
@@ -80,12 +112,12 @@ func SetKey(json []byte, newKey string, path ...string) ([]byte, error) {
 					i++
 				}
 				if curr == 34 {
-					return replace(json, []byte(newKey), keyStart, i), nil
+					return replace(json, []byte(escape(newKey)), keyStart, i), nil
 				}
 			}
-			return json, badJSONError(keyStart)
+			return json, badJSONError(json, start, "'\"'")
 		}
 		return json, err
 	}
-	return json, badJSONError(keyStart)
+	return json, badJSONError(json, keyStart, "")
 }
@@ -1,5 +1,63 @@
 package jin
 
+// Kind is the type of a JSON value.
+type Kind int
+
+// Kinds of JSON values, KindInvalid is the zero value.
+const (
+	KindInvalid Kind = iota
+	KindObject
+	KindArray
+	KindString
+	KindNumber
+	KindBool
+	KindNull
+)
+
+// String returns the name of kind, like "object" or "number".
+func (k Kind) String() string {
+	switch k {
+	case KindObject:
+		return "object"
+	case KindArray:
+		return "array"
+	case KindString:
+		return "string"
+	case KindNumber:
+		return "number"
+	case KindBool:
+		return "bool"
+	case KindNull:
+		return "null"
+	}
+	return "invalid"
+}
+
+// kindOf determines the kind of a raw JSON value by its first character.
+func kindOf(value []byte) Kind {
+	if len(value) == 0 {
+		return KindInvalid
+	}
+	switch curr := value[0]; {
+	case curr == 123:
+		return KindObject
+	case curr == 91:
+		return KindArray
+	case curr == 34:
+		return KindString
+	// t, f
+	case curr == 116 || curr == 102:
+		return KindBool
+	// n
+	case curr == 110:
+		return KindNull
+	// -, digits
+	case curr == 45 || isDigit(curr):
+		return KindNumber
+	}
+	return KindInvalid
+}
+
 // IsObject is a type control function.
 // If path points to an object it will return true, otherwise it will return false.
 // In this instance 'object' means everything that has starts and ends with curly brace.
@@ -33,6 +91,17 @@ func IsValue(json []byte, path ...string) (bool, error) {
 	return state, nil
 }
 
+// IsNull is a type control function.
+// If path points to a null value it will return true, otherwise it will return false.
+// String values like "null" are not null.
+func IsNull(json []byte, path ...string) (bool, error) {
+	state, start, err := typeControlCore(json, []byte{110}, true, path...)
+	if err != nil {
+		return false, err
+	}
+	return state && isNull(json[start:]), nil
+}
+
 // GetType is a type return function.
 // If path points to an value it will return 'value' string.
 // If path points to an array it will return 'array' string.
@@ -54,6 +123,21 @@ func GetType(json []byte, path ...string) (string, error) {
 	return "ERROR", nil
 }
 
+// GetKind is a type return function.
+// Unlike GetType() it distinguishes strings, numbers, booleans and null values.
+// Kind is determined by the first character of the value, so "true" is a string and true is a bool.
+func GetKind(json []byte, path ...string) (Kind, error) {
+	_, start, err := typeControlCore(json, []byte{}, false, path...)
+	if err != nil {
+		return KindInvalid, err
+	}
+	kind := kindOf(json[start:])
+	if kind == KindInvalid {
+		return KindInvalid, badJSONError(json, start, "value")
+	}
+	return kind, nil
+}
+
 // IsEmpty is a control function.
 // If path points to an value it will return 'value' string
 // If path points to an array that has zero element in it,
@@ -65,14 +149,14 @@ func IsEmpty(json []byte, path ...string) (bool, error) {
 	if len(path) == 0 {
 		for space(json[start]) {
 			if start > len(json)-1 {
-				return false, badJSONError(start)
+				return false, badJSONError(json, start, "value")
 			}
 			start++
 			continue
 		}
 		for space(json[end]) {
 			if end < 1 {
-				return false, badJSONError(end)
+				return false, badJSONError(json, end, "value")
 			}
 			end--
 			continue
@@ -88,7 +172,7 @@ func IsEmpty(json []byte, path ...string) (bool, error) {
 	braceEnd := json[end]
 	if braceStart == 91 || braceStart == 123 {
 		if braceStart+2 != braceEnd {
-			return false, badJSONError(end)
+			return false, badJSONError(json, end, "'"+string(braceStart+2)+"'")
 		}
 		for i := start + 1; i < end-1; i++ {
 			if !space(json[i]) {
@@ -107,7 +191,7 @@ func typeControlCore(json []byte, control []byte, equal bool, path ...string) (b
 	if len(path) == 0 {
 		for space(json[start]) {
 			if start > len(json)-1 {
-				return false, -1, badJSONError(start)
+				return false, -1, badJSONError(json, start, "value")
 			}
 			start++
 			continue
@@ -0,0 +1,212 @@
+package jin
+
+import (
+	"bufio"
+	"errors"
+	"io"
+)
+
+// LinePolicy is the policy of LineReader for lines that are not valid JSON.
+type LinePolicy int
+
+const (
+	// LineFail stops at the first bad line, Next() returns its error.
+	LineFail LinePolicy = iota
+	// LineSkip skips bad lines, Skipped() returns how many lines are skipped.
+	LineSkip
+)
+
+// LineReader reads newline-delimited JSON (NDJSON, JSON Lines), one record per line.
+// Blank lines are ignored, line endings can be '\n' or "\r\n".
+// Errors of bad lines have the line number in their Line field, Offset and Column are in that line.
+//
+//	lr := jin.NewLineReader(file, jin.LineSkip)
+//	for {
+//		record, err := lr.Next()
+//		if err == io.EOF {
+//			break
+//		}
+//		...
+//	}
+type LineReader struct {
+	r       *bufio.Reader
+	policy  LinePolicy
+	line    int
+	skipped int
+	// buf keeps the parts of a line that is longer than the buffer of r.
+	buf []byte
+	// raw is the current line, indent is the offset of the record in it.
+	raw    []byte
+	indent int
+}
+
+// NewLineReader returns a LineReader that reads r, lines longer than DefaultMaxBuffer return an error
+// with code ErrValueTooLarge, LineSkip skips them like bad lines.
+func NewLineReader(r io.Reader, policy LinePolicy) *LineReader {
+	return &LineReader{r: bufio.NewReaderSize(r, initialBuffer), policy: policy}
+}
+
+// Next returns the next record, it returns io.EOF after the last record.
+// Record is valid until the next call of Next().
+func (lr *LineReader) Next() ([]byte, error) {
+	for {
+		raw, err := lr.readLine()
+		if errors.Is(err, ErrValueTooLarge) && lr.policy == LineSkip {
+			lr.skipped++
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		record := trimLine(raw)
+		if len(record) == 0 {
+			continue
+		}
+		lr.raw = raw
+		lr.indent = len(raw) - len(trimLeft(raw))
+		if err := ValidateDetailed(record); err != nil {
+			if lr.policy == LineSkip {
+				lr.skipped++
+				continue
+			}
+			return nil, lr.lineError(err)
+		}
+		return record, nil
+	}
+}
+
+// readLine reads the next line without its line ending.
+// Lines longer than DefaultMaxBuffer are read to their end but not kept, they return an error with code ErrValueTooLarge.
+func (lr *LineReader) readLine() ([]byte, error) {
+	lr.buf = lr.buf[:0]
+	size := 0
+	for {
+		// 10 = NL
+		chunk, err := lr.r.ReadSlice(10)
+		size += len(chunk)
+		if err == bufio.ErrBufferFull {
+			if size > DefaultMaxBuffer {
+				lr.buf = lr.buf[:0]
+			} else {
+				lr.buf = append(lr.buf, chunk...)
+			}
+			continue
+		}
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		if err == io.EOF && size == 0 {
+			return nil, io.EOF
+		}
+		lr.line++
+		line := chunk
+		if len(lr.buf) > 0 {
+			lr.buf = append(lr.buf, chunk...)
+			line = lr.buf
+		}
+		if len(chunk) > 0 && chunk[len(chunk)-1] == 10 {
+			size--
+			line = line[:len(line)-1]
+		}
+		if size > DefaultMaxBuffer {
+			e := valueTooLargeError(DefaultMaxBuffer)
+			e.Line = lr.line
+			return nil, e
+		}
+		return line, nil
+	}
+}
+
+// NextParser is a variation of Next() func, it returns the next record as a *Parser.
+// Parser has its own copy of the record.
+func (lr *LineReader) NextParser() (*Parser, error) {
+	for {
+		record, err := lr.Next()
+		if err != nil {
+			return nil, err
+		}
+		pars, err := Parse(append([]byte(nil), record...))
+		if err == nil {
+			return pars, nil
+		}
+		if lr.policy == LineFail {
+			return nil, lr.lineError(err)
+		}
+		lr.skipped++
+	}
+}
+
+// Line returns the line number of the last record, first line is 1.
+func (lr *LineReader) Line() int {
+	return lr.line
+}
+
+// Skipped returns the number of bad lines that are skipped with LineSkip policy.
+func (lr *LineReader) Skipped() int {
+	return lr.skipped
+}
+
+// lineError sets the line of err to the current line,
+// offset and column of err are moved from the record to the line, like spaces before the record are counted.
+func (lr *LineReader) lineError(err error) error {
+	if e, ok := err.(*Error); ok {
+		if e.Offset >= 0 {
+			e.in(lr.raw, e.Offset+lr.indent)
+		}
+		e.Line = lr.line
+	}
+	return err
+}
+
+// trimLine trims spaces and the carriage return of a line.
+func trimLine(line []byte) []byte {
+	line = trimLeft(line)
+	end := len(line)
+	for end > 0 && space(line[end-1]) {
+		end--
+	}
+	return line[:end]
+}
+
+// trimLeft trims spaces at the start of a line.
+func trimLeft(line []byte) []byte {
+	start := 0
+	for start < len(line) && space(line[start]) {
+		start++
+	}
+	return line[start:]
+}
+
+// LineWriter writes newline-delimited JSON (NDJSON, JSON Lines), one record per line.
+type LineWriter struct {
+	w io.Writer
+}
+
+// NewLineWriter returns a LineWriter that writes to w.
+func NewLineWriter(w io.Writer) *LineWriter {
+	return &LineWriter{w: w}
+}
+
+// WriteRecord compacts record with Flatten() and writes it as a line.
+// Record must be a valid JSON, otherwise it returns the error of ValidateDetailed() and writes nothing.
+func (lw *LineWriter) WriteRecord(record []byte) error {
+	if err := ValidateDetailed(record); err != nil {
+		return err
+	}
+	line := Flatten(record)
+	// 10 = NL
+	line = append(line, 10)
+	_, err := lw.w.Write(line)
+	return err
+}
+
+// WriteValue writes v as a line like Marshal() writes it.
+func (lw *LineWriter) WriteValue(v interface{}) error {
+	record, err := Marshal(v)
+	if err != nil {
+		return err
+	}
+	// 10 = NL
+	_, err = lw.w.Write(append(record, 10))
+	return err
+}
@@ -0,0 +1,82 @@
+package jin
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLineReaderLongLines(t *testing.T) {
+	long := `{"a":"` + strings.Repeat("x", DefaultMaxBuffer) + `"}`
+	// a line that is longer than the buffer of the reader but not than DefaultMaxBuffer.
+	fit := `{"b":"` + strings.Repeat("y", 3*initialBuffer) + `"}`
+	input := "{\"n\":1}\n" + long + "\n" + fit + "\r\n" + long + "\n{\"n\":2}"
+
+	lr := NewLineReader(strings.NewReader(input), LineSkip)
+	var got []string
+	for {
+		record, err := lr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, string(record))
+	}
+	if len(got) != 3 || got[0] != `{"n":1}` || got[1] != fit || got[2] != `{"n":2}` {
+		t.Errorf("got %v records, want the long lines skipped", len(got))
+	}
+	if lr.Skipped() != 2 || lr.Line() != 5 {
+		t.Errorf("got skipped %v line %v, want skipped 2 line 5", lr.Skipped(), lr.Line())
+	}
+
+	lr = NewLineReader(strings.NewReader(input), LineFail)
+	lr.Next()
+	_, err := lr.Next()
+	var e *Error
+	if !errors.As(err, &e) || !errors.Is(err, ErrValueTooLarge) || e.Line != 2 {
+		t.Fatalf("got %v, want ErrValueTooLarge at line 2", err)
+	}
+	record, err := lr.Next()
+	if err != nil || string(record) != fit || lr.Line() != 3 {
+		t.Errorf("got line %v error %v, want reading to go on with line 3", lr.Line(), err)
+	}
+}
+
+func TestLineReaderErrorColumns(t *testing.T) {
+	tests := []struct {
+		input  string
+		line   int
+		column int
+		offset int
+	}{
+		{"{\"a\" 1}", 1, 6, 5},
+		{"   {\"a\" 1}", 1, 9, 8},
+		{"\t\t[1,]\r\n", 1, 6, 5},
+		{"{}\n\n  \"é\x01\"", 3, 5, 5},
+		{"{}\n \"é\" , 1 ", 2, 6, 6},
+	}
+	for _, test := range tests {
+		lr := NewLineReader(strings.NewReader(test.input), LineFail)
+		var err error
+		for err == nil {
+			_, err = lr.Next()
+		}
+		var e *Error
+		if !errors.As(err, &e) || e.Line != test.line || e.Column != test.column || e.Offset != test.offset {
+			t.Errorf("%q: got %v, want line %v column %v offset %v", test.input, err, test.line, test.column, test.offset)
+		}
+	}
+}
+
+func TestLineReaderParserErrorColumns(t *testing.T) {
+	lr := NewLineReader(strings.NewReader("{}\n   42 \n"), LineFail)
+	lr.NextParser()
+	_, err := lr.NextParser()
+	var e *Error
+	if !errors.As(err, &e) || e.Line != 2 || e.Column != 4 || e.Offset != 3 {
+		t.Errorf("got %v, want line 2 column 4 offset 3", err)
+	}
+}
@@ -1,9 +1,6 @@
 package jin
 
-import (
-	"fmt"
-	"strconv"
-)
+import "strconv"
 
 // Scheme is a tool for creating non-nested JSONs.
 // It provides a struct for saving a JSON scheme for later usage.
@@ -23,12 +20,19 @@ func MakeScheme(keys ...string) *Scheme {
 
 // MakeJson is main creation method for creating JSON's from Schemes.
 // More information on Type Scheme example.
+// nil means failure, it returns nil if values can not be written, use MakeJsonChecked() for the error.
 func (s *Scheme) MakeJson(values ...interface{}) []byte {
 	return MakeJson(s.keys, values)
 }
 
+// MakeJsonChecked is a variation of MakeJson() func, it returns an error instead of nil.
+func (s *Scheme) MakeJsonChecked(values ...interface{}) ([]byte, error) {
+	return MakeJsonChecked(s.keys, values)
+}
+
 // MakeJsonString is main creation method for creating JSON's from Schemes.
 // More information on Type Scheme example.
+// nil means failure, it returns nil if number of values is not same with the keys of the Scheme.
 func (s *Scheme) MakeJsonString(values ...string) []byte {
 	return MakeJsonString(s.keys, values)
 }
@@ -40,8 +44,7 @@ func (s *Scheme) MutateJson(json []byte) ([]byte, error) {
 	var val string
 	for _, k := range s.keys {
 		val, err = GetString(json, k)
-		val = formatType(val)
-		newJson, err2 = AddKeyValueString(newJson, k, val)
+		newJson, err2 = AddKeyValue(newJson, k, []byte(formatType(val)))
 		if err == nil {
 			if err2 != nil {
 				return nil, err
@@ -61,8 +64,7 @@ func (s *Scheme) MutateJsonAbs(json []byte) ([]byte, error) {
 		if err != nil {
 			return nil, err
 		}
-		val = formatType(val)
-		newJson, err = AddKeyValueString(newJson, k, val)
+		newJson, err = AddKeyValue(newJson, k, []byte(formatType(val)))
 		if err != nil {
 			return nil, err
 		}
@@ -164,21 +166,37 @@ func MakeEmptyArray() []byte {
 }
 
 // MakeArray creates an array formation from given values and returns them as byte slice.
-// Do not use any slice/array for parameter.
-// It will accept this kind types but won't be able to make valid representation for use!
+// Values are written like Marshal() does, so slices, maps, structs and pointers are written as JSON.
+// Strings are the exception, strings that are JSON values like "42", "true" or "[1]" are written as they are.
+// nil means failure, it returns nil if a value can not be marshaled, like a NaN float or a channel,
+// use MakeArrayChecked() for the error.
 func MakeArray(elements ...interface{}) []byte {
+	js, err := MakeArrayChecked(elements...)
+	if err != nil {
+		return nil
+	}
+	return js
+}
+
+// MakeArrayChecked is a variation of MakeArray() func, it returns an error instead of nil,
+// with code ErrBadFloat for NaN and Inf values and ErrMarshal for values like channels.
+func MakeArrayChecked(elements ...interface{}) ([]byte, error) {
 	if elements == nil {
-		return []byte{91, 93}
+		return []byte{91, 93}, nil
 	}
 	js := make([]byte, 0, 128)
 	js = append(js, 91)
 	for _, e := range elements {
-		js = append(js, []byte(formatType(fmt.Sprintf("%v", e)))...)
+		val, err := formatElement(e)
+		if err != nil {
+			return nil, err
+		}
+		js = append(js, []byte(val)...)
 		js = append(js, 44)
 	}
 	js = js[:len(js)-1]
 	js = append(js, 93)
-	return js
+	return js, nil
 }
 
 // MakeArrayString is a variation of MakeArray() func.
@@ -242,31 +260,34 @@ func MakeArrayBool(values []bool) []byte {
 // MakeArrayFloat is a variation of MakeArray() func.
 // Parameter type must be slice of float64.
 // For more information look MakeArray() function.
+// nil means failure, it returns nil if there is a NaN or Inf value, use MakeArrayFloatChecked() for the error.
 func MakeArrayFloat(values []float64) []byte {
+	js, err := MakeArrayFloatChecked(values)
+	if err != nil {
+		return nil
+	}
+	return js
+}
+
+// MakeArrayFloatChecked is a variation of MakeArrayFloat() func,
+// it returns an error with code ErrBadFloat instead of nil for NaN and Inf values.
+func MakeArrayFloatChecked(values []float64) ([]byte, error) {
 	if values == nil {
-		return []byte{91, 93}
+		return []byte{91, 93}, nil
 	}
 	js := make([]byte, 0, 128)
 	js = append(js, 91)
 	for _, v := range values {
-		num := strconv.FormatFloat(v, 'e', -1, 64)
-		start := 0
-		for i := 0; i < len(num); i++ {
-			if num[i] == 'e' {
-				start = i
-			}
-		}
-		exp, _ := strconv.Atoi(num[start+2:])
-		if exp == 0 {
-			js = append(js, []byte(num[:start])...)
-		} else {
-			js = append(js, []byte(num)...)
+		num, err := FormatFloat(v, -1)
+		if err != nil {
+			return nil, err
 		}
+		js = append(js, []byte(num)...)
 		js = append(js, 44)
 	}
 	js = js[:len(js)-1]
 	js = append(js, 93)
-	return js
+	return js, nil
 }
 
 // MakeArrayBytes is a variation of MakeArray() func.
@@ -293,31 +314,47 @@ func MakeEmptyJson() []byte {
 }
 
 // MakeJson creates an JSON formation from given key and value slices, and returns them as byte slice.
-// Do not use any slice/array for 'values' variable parameter.
-// It will accept this kind types but won't be able to make valid representation for use!
+// Values are written like Marshal() does, so slices, maps, structs and pointers are written as JSON.
+// Strings are the exception, strings that are JSON values like "42", "true" or "[1]" are written as they are.
+// nil means failure, it returns nil if lengths of keys and values are not same or a value can not be marshaled,
+// like a NaN float or a channel, use MakeJsonChecked() for the error.
 func MakeJson(keys []string, values []interface{}) []byte {
-	if len(keys) != len(values) {
+	js, err := MakeJsonChecked(keys, values)
+	if err != nil {
 		return nil
 	}
+	return js
+}
+
+// MakeJsonChecked is a variation of MakeJson() func, it returns an error instead of nil,
+// with code ErrLengthMismatch for keys and values with different lengths,
+// ErrBadFloat for NaN and Inf values and ErrMarshal for values like channels.
+func MakeJsonChecked(keys []string, values []interface{}) ([]byte, error) {
+	if len(keys) != len(values) {
+		return nil, lengthMismatchError(len(keys), len(values))
+	}
 	if keys == nil {
-		return []byte{123, 125}
+		return []byte{123, 125}, nil
 	}
 	js := make([]byte, 0, 128)
 	js = append(js, 123)
 	for i, k := range keys {
-		js = append(js, 34)
-		js = append(js, []byte(k)...)
-		js = append(js, 34)
+		js = appendQuoted(js, k, false)
 		js = append(js, 58)
-		js = append(js, []byte(formatType(fmt.Sprintf("%v", values[i])))...)
+		val, err := formatElement(values[i])
+		if err != nil {
+			return nil, err
+		}
+		js = append(js, []byte(val)...)
 		js = append(js, 44)
 	}
 	js = js[:len(js)-1]
 	js = append(js, 125)
-	return js
+	return js, nil
 }
 
 // MakeJsonString creates an JSON formation from given key and value string slices, and returns them as byte slice.
+// nil means failure, it returns nil if lengths of keys and values are not same.
 func MakeJsonString(keys, values []string) []byte {
 	if len(keys) != len(values) {
 		return nil
@@ -328,9 +365,7 @@ func MakeJsonString(keys, values []string) []byte {
 	js := make([]byte, 0, 128)
 	js = append(js, 123)
 	for i, k := range keys {
-		js = append(js, 34)
-		js = append(js, []byte(k)...)
-		js = append(js, 34)
+		js = appendQuoted(js, k, false)
 		js = append(js, 58)
 		js = append(js, []byte(formatType(values[i]))...)
 		js = append(js, 44)
@@ -348,9 +383,7 @@ func MakeJsonWithMap(json map[string]string) []byte {
 	js := make([]byte, 0, 128)
 	js = append(js, 123)
 	for k, v := range json {
-		js = append(js, 34)
-		js = append(js, []byte(k)...)
-		js = append(js, 34)
+		js = appendQuoted(js, k, false)
 		js = append(js, 58)
 		js = append(js, []byte(formatType(v))...)
 		js = append(js, 44)
@@ -359,3 +392,17 @@ func MakeJsonWithMap(json map[string]string) []byte {
 	js = append(js, 125)
 	return js
 }
+
+// formatElement formats a value of MakeArray() or MakeJson().
+// Strings are formatted with formatType(), so "42" or "true" are written as they are,
+// any other value is written like Marshal() does.
+func formatElement(value interface{}) (string, error) {
+	if str, ok := value.(string); ok {
+		return formatType(str), nil
+	}
+	val, err := Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(val), nil
+}
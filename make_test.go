@@ -0,0 +1,64 @@
+package jin
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestMakeErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		make    func() []byte
+		checked func() ([]byte, error)
+		want    error
+	}{
+		{"array NaN",
+			func() []byte { return MakeArray(1, math.NaN()) },
+			func() ([]byte, error) { return MakeArrayChecked(1, math.NaN()) }, ErrBadFloat},
+		{"array channel",
+			func() []byte { return MakeArray(make(chan int)) },
+			func() ([]byte, error) { return MakeArrayChecked(make(chan int)) }, ErrMarshal},
+		{"float array Inf",
+			func() []byte { return MakeArrayFloat([]float64{1, math.Inf(-1)}) },
+			func() ([]byte, error) { return MakeArrayFloatChecked([]float64{1, math.Inf(-1)}) }, ErrBadFloat},
+		{"json Inf",
+			func() []byte { return MakeJson([]string{"a"}, []interface{}{math.Inf(1)}) },
+			func() ([]byte, error) { return MakeJsonChecked([]string{"a"}, []interface{}{math.Inf(1)}) }, ErrBadFloat},
+		{"json length",
+			func() []byte { return MakeJson([]string{"a", "b"}, []interface{}{1}) },
+			func() ([]byte, error) { return MakeJsonChecked([]string{"a", "b"}, []interface{}{1}) }, ErrLengthMismatch},
+		{"scheme channel",
+			func() []byte { return MakeScheme("a").MakeJson(make(chan int)) },
+			func() ([]byte, error) { return MakeScheme("a").MakeJsonChecked(make(chan int)) }, ErrMarshal},
+	}
+	for _, test := range tests {
+		if js := test.make(); js != nil {
+			t.Errorf("%v: got %s, want nil", test.name, js)
+		}
+		js, err := test.checked()
+		if js != nil || !errors.Is(err, test.want) {
+			t.Errorf("%v: got %s %v, want %v", test.name, js, err, test.want)
+		}
+	}
+	js, err := MakeJsonChecked([]string{"a", "b"}, []interface{}{1.5, []int{1}})
+	if err != nil || string(js) != `{"a":1.5,"b":[1]}` {
+		t.Errorf("got %s %v", js, err)
+	}
+	js, err = MakeArrayChecked(1, "x", nil)
+	if err != nil || string(js) != `[1,"x",null]` {
+		t.Errorf("got %s %v", js, err)
+	}
+}
+
+func TestLengthMismatchError(t *testing.T) {
+	_, err := MakeJsonChecked([]string{"a", "b"}, []interface{}{1})
+	var e *Error
+	if !errors.As(err, &e) || e.Code != ErrLengthMismatch.Code || e.Offset != -1 || e.Segment != "" || e.Expected != "" {
+		t.Fatalf("got %#v, want a bare ErrLengthMismatch", err)
+	}
+	if want := "error: keys and values must have the same length, 2 keys 1 values."; !strings.Contains(e.Error(), want) {
+		t.Errorf("got %q, want it to contain %q", e.Error(), want)
+	}
+}
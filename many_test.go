@@ -0,0 +1,98 @@
+package jin
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGetMany(t *testing.T) {
+	json := []byte(`{
+		"user": {"name": "eco", "tags": ["a", "b", {"c": [1, 2]}], "e\"q": true},
+		"id": 7,
+		"empty": {},
+		"text": "abc"
+	}`)
+	paths := [][]string{
+		{},
+		{"user"},
+		{"user", "name"},
+		{"user", "tags"},
+		{"user", "tags", "2", "c", "1"},
+		{"user", "tags", "2", "c"},
+		{"user", "tags", "0"},
+		{"user", "tags", "-1", "c", "0"},
+		{"user", "tags", "01"},
+		{"user", "tags", "1"},
+		{"user", `e"q`},
+		{"user", "missing"},
+		{"user", "tags", "5"},
+		{"user", "tags", "x"},
+		{"user", "name", "x"},
+		{"id"},
+		{"id"},
+		{"text", "0"},
+		{"empty", "a"},
+		{"missing", "a", "b"},
+	}
+	values, errs := GetMany(json, paths)
+	for i, path := range paths {
+		value, err := Get(json, path...)
+		if string(values[i]) != string(value) || !sameError(errs[i], err) {
+			t.Errorf("%v: got %q %v, Get() returns %q %v", path, values[i], errs[i], value, err)
+		}
+	}
+	called := make([]int, len(paths))
+	GetManyFunc(json, paths, func(index int, value []byte, err error) {
+		called[index]++
+	})
+	for i, count := range called {
+		if count != 1 {
+			t.Errorf("%v: callback is called %v times, want once", paths[i], count)
+		}
+	}
+}
+
+func TestGetManyMalformed(t *testing.T) {
+	tests := []struct {
+		json   string
+		paths  [][]string
+		values []string
+		offset []int
+	}{
+		// the values before the malformed part are found, paths after it get the error.
+		{`{"a":1,"b":[1,}`, [][]string{{"a"}, {"c"}}, []string{"1", ""}, []int{-1, 15}},
+		{`{"a":[1,2,}`, [][]string{{"a", "0"}, {"a", "5"}}, []string{"1", ""}, []int{-1, 10}},
+		{`{"a":{"b":1 "c":2}}`, [][]string{{"a", "b"}, {"a", "c"}, {"a"}}, []string{"1", "", ""}, []int{-1, 12, 12}},
+		{`{"a":[{"b":1},{"b":2`, [][]string{{"a", "0", "b"}, {"a", "1", "b"}, {"a"}}, []string{"1", "2", ""}, []int{-1, -1, 20}},
+		{`{"a" 1}`, [][]string{{"a"}, {"a", "b"}}, []string{"", ""}, []int{5, 5}},
+		{`[1,2`, [][]string{{"0"}, {"-1"}}, []string{"1", ""}, []int{-1, 4}},
+		{`{"a":"abc}`, [][]string{{"a"}, {"b"}}, []string{"", ""}, []int{10, 10}},
+	}
+	for _, test := range tests {
+		values, errs := GetMany([]byte(test.json), test.paths)
+		for i, path := range test.paths {
+			if string(values[i]) != test.values[i] {
+				t.Errorf("%v %v: got value %q, want %q", test.json, path, values[i], test.values[i])
+			}
+			if test.offset[i] == -1 {
+				if errs[i] != nil {
+					t.Errorf("%v %v: unexpected error %v", test.json, path, errs[i])
+				}
+				continue
+			}
+			var e *Error
+			if !errors.As(errs[i], &e) || !errors.Is(errs[i], ErrBadJSON) || e.Offset != test.offset[i] {
+				t.Errorf("%v %v: got %v, want a bad json error at %v", test.json, path, errs[i], test.offset[i])
+			}
+		}
+	}
+}
+
+// sameError reports whether a and b are both nil or have the same code.
+func sameError(a, b error) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	var ea, eb *Error
+	return errors.As(a, &ea) && errors.As(b, &eb) && ea.Code == eb.Code
+}
@@ -0,0 +1,333 @@
+package jin
+
+import (
+	"encoding"
+	"encoding/base64"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// Marshaler is the interface of types that can write themselves as JSON.
+// It is same with json.Marshaler of the standard library, so types that implement it work with Marshal() too.
+type Marshaler interface {
+	MarshalJSON() ([]byte, error)
+}
+
+// maxMarshalDepth is the nesting limit of Marshal(), deeper values are most likely cyclic.
+const maxMarshalDepth = 1000
+
+var (
+	marshalerType     = reflect.TypeOf((*Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// Marshal returns v as JSON.
+// Struct fields are written with their 'jin' tags like Unmarshal() reads them,
+// paths of tags are written as nested objects, so a struct reads and writes the same JSON.
+//
+//	type Repo struct {
+//		Name  string   `jin:"repo.name"`
+//		Owner string   `jin:"repo.owner.login"`
+//		Tags  []string `jin:"tags,omitempty"`
+//		Cache int      `jin:"-"`
+//	}
+//	// {"repo":{"name":"jin","owner":{"login":"ecoshub"}},"tags":["json"]}
+//
+// Fields without a 'jin' tag are written with the name of their 'json' tag, like encoding/json writes them.
+// Fields without a tag are written with their names, 'omitempty' option skips false, 0, "",
+// nil pointers, nil interfaces and empty arrays, slices and maps. Fields that have an array index in their path,
+// like 'tags[0]' are only read by Unmarshal(), Marshal() skips them.
+// Values that implement Marshaler are written as MarshalJSON() returns,
+// values that implement encoding.TextMarshaler are written as strings.
+// Strings are escaped like Quote() does, floats are written like FormatFloat() does,
+// []byte values are written as base64 strings, maps are written with sorted keys.
+// Channels, functions, complex numbers and cyclic values return an error with code ErrMarshal.
+func Marshal(v interface{}) ([]byte, error) {
+	buf, err := appendValue(make([]byte, 0, 128), reflect.ValueOf(v), "", 0)
+	if err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// appendValue appends v as JSON to buf, field is the name of the field for errors.
+func appendValue(buf []byte, v reflect.Value, field string, depth int) ([]byte, error) {
+	if !v.IsValid() {
+		return append(buf, "null"...), nil
+	}
+	t := v.Type()
+	if depth > maxMarshalDepth {
+		return nil, fieldError(marshalDepthError(t.String()), field)
+	}
+	if t == numberType {
+		if !isNumber(v.String()) {
+			return nil, fieldError(numberParseError(v.String()), field)
+		}
+		return append(buf, v.String()...), nil
+	}
+	// values of unexported embedded structs can not call methods.
+	if !v.CanInterface() {
+		return appendKind(buf, v, field, depth)
+	}
+	// big.Float is a number, not a string like MarshalText() of it writes.
+	switch t {
+	case bigFloatType:
+		p := reflect.New(t)
+		p.Elem().Set(v)
+		return p.Interface().(*big.Float).Append(buf, 'g', -1), nil
+	case reflect.PtrTo(bigFloatType):
+		if v.IsNil() {
+			return append(buf, "null"...), nil
+		}
+		return v.Interface().(*big.Float).Append(buf, 'g', -1), nil
+	}
+	// methods with pointer receivers, like MarshalJSON() of *big.Int.
+	if v.Kind() != reflect.Ptr && v.Kind() != reflect.Interface &&
+		(reflect.PtrTo(t).Implements(marshalerType) || reflect.PtrTo(t).Implements(textMarshalerType)) {
+		if v.CanAddr() {
+			v = v.Addr()
+		} else {
+			p := reflect.New(t)
+			p.Elem().Set(v)
+			v = p
+		}
+		t = v.Type()
+	}
+	if t.Implements(marshalerType) || t.Implements(textMarshalerType) {
+		if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+			return append(buf, "null"...), nil
+		}
+		if m, ok := v.Interface().(Marshaler); ok {
+			raw, err := m.MarshalJSON()
+			if err != nil {
+				return nil, fieldError(marshalerError(t.String(), err), field)
+			}
+			if err := ValidateDetailed(raw); err != nil {
+				return nil, fieldError(err, field)
+			}
+			return append(buf, trim(raw)...), nil
+		}
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return nil, fieldError(marshalerError(t.String(), err), field)
+		}
+		return appendQuoted(buf, string(text), false), nil
+	}
+	return appendKind(buf, v, field, depth)
+}
+
+// appendKind appends v as JSON by its kind, without checking its methods.
+func appendKind(buf []byte, v reflect.Value, field string, depth int) ([]byte, error) {
+	t := v.Type()
+	switch v.Kind() {
+	case reflect.Bool:
+		return strconv.AppendBool(buf, v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(buf, v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.AppendUint(buf, v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		num, err := formatFloat(v.Float(), -1, t.Bits())
+		if err != nil {
+			return nil, fieldError(err, field)
+		}
+		return append(buf, num...), nil
+	case reflect.String:
+		return appendQuoted(buf, v.String(), false), nil
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return append(buf, "null"...), nil
+		}
+		return appendValue(buf, v.Elem(), field, depth+1)
+	case reflect.Slice:
+		if v.IsNil() {
+			return append(buf, "null"...), nil
+		}
+		if t.Elem().Kind() == reflect.Uint8 && !reflect.PtrTo(t.Elem()).Implements(marshalerType) &&
+			!reflect.PtrTo(t.Elem()).Implements(textMarshalerType) {
+			return appendBase64(buf, v.Bytes()), nil
+		}
+		return appendArray(buf, v, field, depth)
+	case reflect.Array:
+		return appendArray(buf, v, field, depth)
+	case reflect.Map:
+		if v.IsNil() {
+			return append(buf, "null"...), nil
+		}
+		return appendMap(buf, v, field, depth)
+	case reflect.Struct:
+		plan, err := typePlan(t)
+		if err != nil {
+			return nil, err
+		}
+		buf, _, err = appendStruct(buf, v, plan, field, depth)
+		return buf, err
+	}
+	return nil, fieldError(marshalError(t.String()), field)
+}
+
+func appendArray(buf []byte, v reflect.Value, field string, depth int) ([]byte, error) {
+	var err error
+	// 91 = [
+	buf = append(buf, 91)
+	for i := 0; i < v.Len(); i++ {
+		if i > 0 {
+			buf = append(buf, 44)
+		}
+		buf, err = appendValue(buf, v.Index(i), field+"["+strconv.Itoa(i)+"]", depth+1)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// 93 = ]
+	return append(buf, 93), nil
+}
+
+// appendMap appends a map as an object, keys are sorted so output is always same for same map.
+// Keys can be strings, integers or encoding.TextMarshaler values.
+func appendMap(buf []byte, v reflect.Value, field string, depth int) ([]byte, error) {
+	type member struct {
+		key   string
+		value reflect.Value
+	}
+	members := make([]member, 0, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		k := iter.Key()
+		var key string
+		switch {
+		case k.Kind() == reflect.String:
+			key = k.String()
+		case k.Type().Implements(textMarshalerType):
+			// nil keys have no text, writing them as "" would make duplicate keys.
+			if k.Kind() == reflect.Ptr && k.IsNil() {
+				return nil, fieldError(marshalError(k.Type().String()), field)
+			}
+			text, err := k.Interface().(encoding.TextMarshaler).MarshalText()
+			if err != nil {
+				return nil, fieldError(marshalerError(k.Type().String(), err), field)
+			}
+			key = string(text)
+		case k.Kind() >= reflect.Int && k.Kind() <= reflect.Int64:
+			key = strconv.FormatInt(k.Int(), 10)
+		case k.Kind() >= reflect.Uint && k.Kind() <= reflect.Uintptr:
+			key = strconv.FormatUint(k.Uint(), 10)
+		default:
+			return nil, fieldError(marshalError(v.Type().String()), field)
+		}
+		members = append(members, member{key: key, value: iter.Value()})
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].key < members[j].key
+	})
+	var err error
+	// 123 = {
+	buf = append(buf, 123)
+	for i, m := range members {
+		if i > 0 {
+			buf = append(buf, 44)
+		}
+		buf = appendQuoted(buf, m.key, false)
+		// 58 = :
+		buf = append(buf, 58)
+		buf, err = appendValue(buf, m.value, field+"["+m.key+"]", depth+1)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// 125 = }
+	return append(buf, 125), nil
+}
+
+// appendStruct appends the fields of v as an object, n is the level of the field tree.
+// It returns the number of members written, levels without any member are not written by parent levels.
+func appendStruct(buf []byte, v reflect.Value, n *planNode, field string, depth int) ([]byte, int, error) {
+	var err error
+	count := 0
+	// 123 = {
+	buf = append(buf, 123)
+	for _, label := range n.labels {
+		child := n.children[label]
+		start := len(buf)
+		if count > 0 {
+			buf = append(buf, 44)
+		}
+		buf = appendQuoted(buf, label, false)
+		// 58 = :
+		buf = append(buf, 58)
+		written := false
+		for _, f := range child.fields {
+			fv, ok := fieldValue(v, f.index)
+			if !ok || f.indexed || (f.omitEmpty && isEmptyValue(fv)) {
+				continue
+			}
+			buf, err = appendValue(buf, fv, joinField(field, f.name), depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			written = true
+			break
+		}
+		if !written && len(child.labels) > 0 {
+			var members int
+			buf, members, err = appendStruct(buf, v, child, field, depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			written = members > 0
+		}
+		if !written {
+			buf = buf[:start]
+			continue
+		}
+		count++
+	}
+	// 125 = }
+	return append(buf, 125), count, nil
+}
+
+// fieldValue is like reflect.Value.FieldByIndex,
+// ok is false if an embedded struct pointer on the way is nil.
+func fieldValue(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// isEmptyValue reports whether v is empty for 'omitempty' option.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
+
+// appendBase64 appends data as a base64 string with standard encoding.
+func appendBase64(buf []byte, data []byte) []byte {
+	// 34 = "
+	buf = append(buf, 34)
+	start := len(buf)
+	buf = append(buf, make([]byte, base64.StdEncoding.EncodedLen(len(data)))...)
+	base64.StdEncoding.Encode(buf[start:], data)
+	return append(buf, 34)
+}
@@ -0,0 +1,67 @@
+package jin
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type jsonTagged struct {
+	Name    string `json:"name"`
+	Dotted  int    `json:"a.b"`
+	Dash    int    `json:"-,"`
+	Skipped int    `json:"-"`
+	Empty   string `json:",omitempty"`
+	Both    string `jin:"x.y" json:"both"`
+	Plain   bool
+}
+
+func TestMarshalJSONTags(t *testing.T) {
+	v := jsonTagged{Name: "eco", Dotted: 1, Dash: 2, Skipped: 3, Both: "z", Plain: true}
+	got, err := Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"name":"eco","a.b":1,"-":2,"x":{"y":"z"},"Plain":true}`
+	if string(got) != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+	var back jsonTagged
+	if err := Unmarshal(got, &back); err != nil {
+		t.Fatal(err)
+	}
+	v.Skipped = 0
+	if !reflect.DeepEqual(back, v) {
+		t.Errorf("round trip got %+v, want %+v", back, v)
+	}
+	// json tag is not used if there is a jin tag.
+	var other jsonTagged
+	if err := Unmarshal([]byte(`{"both":"q","Skipped":5,"empty":"e"}`), &other); err != nil {
+		t.Fatal(err)
+	}
+	if other.Both != "" || other.Skipped != 0 || other.Empty != "e" {
+		t.Errorf("got %+v, want only Empty to be set", other)
+	}
+}
+
+type textKey struct {
+	name string
+}
+
+func (k *textKey) MarshalText() ([]byte, error) {
+	return []byte(k.name), nil
+}
+
+func TestMarshalTextMarshalerKeys(t *testing.T) {
+	got, err := Marshal(map[*textKey]int{{name: "b"}: 2, {name: "a"}: 1})
+	if err != nil || string(got) != `{"a":1,"b":2}` {
+		t.Errorf("got %s %v, want {\"a\":1,\"b\":2}", got, err)
+	}
+	for _, m := range []map[*textKey]int{{nil: 1}, {nil: 1, {name: "a"}: 2}} {
+		got, err := Marshal(struct{ M map[*textKey]int }{m})
+		var e *Error
+		if !errors.As(err, &e) || !errors.Is(err, ErrMarshal) || e.Segment != "M" {
+			t.Errorf("got %s %v, want ErrMarshal for the nil key of M", got, err)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package jin
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// Number is a JSON number in its exact lexical form, like json.Number of the standard library.
+// It does not lose precision until it is converted to a Go type.
+type Number string
+
+// String returns the number as it is in JSON.
+func (n Number) String() string {
+	return string(n)
+}
+
+// Int64 returns the number as int64.
+// Numbers that does not fit to int64 return an error with code ErrNumberOverflow.
+func (n Number) Int64() (int64, error) {
+	val := string(n)
+	num, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return 0, rangeError(err, val, "int64", intParseError(val))
+	}
+	return num, nil
+}
+
+// Uint64 returns the number as uint64.
+// Numbers that does not fit to uint64, negative numbers included,
+// return an error with code ErrNumberOverflow.
+func (n Number) Uint64() (uint64, error) {
+	val := string(n)
+	num, err := strconv.ParseUint(val, 10, 64)
+	if err != nil {
+		// 45 = -
+		if len(val) > 1 && val[0] == 45 && isInteger(val[1:]) {
+			return 0, numberOverflowError(val, "uint64")
+		}
+		return 0, rangeError(err, val, "uint64", intParseError(val))
+	}
+	return num, nil
+}
+
+// Float64 returns the number as float64.
+// Numbers that are out of float64 range return an error with code ErrNumberOverflow.
+func (n Number) Float64() (float64, error) {
+	val := string(n)
+	num, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return 0, rangeError(err, val, "float64", floatParseError(val))
+	}
+	return num, nil
+}
+
+// BigInt returns the number as *big.Int, it never overflows.
+// Numbers that has fraction or exponent parts can not be converted.
+func (n Number) BigInt() (*big.Int, error) {
+	val := string(n)
+	if !isNumber(val) {
+		return nil, intParseError(val)
+	}
+	num, ok := new(big.Int).SetString(val, 10)
+	if !ok {
+		return nil, intParseError(val)
+	}
+	return num, nil
+}
+
+// BigFloat returns the number as *big.Float.
+// Precision of the result is enough to hold all decimal digits of the number.
+func (n Number) BigFloat() (*big.Float, error) {
+	val := string(n)
+	if !isNumber(val) {
+		return nil, floatParseError(val)
+	}
+	// log2(10) < 4 bits for every decimal digit.
+	prec := uint(len(val)) * 4
+	if prec < 64 {
+		prec = 64
+	}
+	num, _, err := big.ParseFloat(val, 10, prec, big.ToNearestEven)
+	if err != nil {
+		return nil, floatParseError(val)
+	}
+	return num, nil
+}
+
+// FormatFloat returns value as a JSON number.
+// precision is the number of digits after the decimal point,
+// -1 is for the shortest representation that reads back to the same value, like 12.5 or 1e-7.
+// All functions that writes floats, like SetFloat() or MakeArrayFloat() use the shortest representation,
+// FormatFloat() can be used with Set(), Add() or Insert() for a fixed precision.
+// NaN and Inf values return an error with code ErrBadFloat.
+func FormatFloat(value float64, precision int) (string, error) {
+	return formatFloat(value, precision, 64)
+}
+
+// formatFloat is the implementation of FormatFloat(), bitSize is 32 for float32 values.
+func formatFloat(value float64, precision int, bitSize int) (string, error) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return "", badFloatError(strconv.FormatFloat(value, 'g', -1, 64))
+	}
+	if precision >= 0 {
+		return strconv.FormatFloat(value, 'f', precision, bitSize), nil
+	}
+	// same limits with encoding/json, exponent is used for very small and very big numbers.
+	abs := math.Abs(value)
+	if abs == 0 || (abs >= 1e-6 && abs < 1e21) {
+		return strconv.FormatFloat(value, 'f', -1, bitSize), nil
+	}
+	num := strconv.FormatFloat(value, 'e', -1, bitSize)
+	// 1e-07 to 1e-7
+	n := len(num)
+	if n >= 4 && num[n-4] == 101 && num[n-3] == 45 && num[n-2] == 48 {
+		num = num[:n-2] + num[n-1:]
+	}
+	return num, nil
+}
+
+// toNumber converts a value to Number, quoted numbers like "42" are accepted like GetInt() does.
+func toNumber(val string) (Number, error) {
+	val = stripQuotes(val)
+	if len(val) == 0 || !isNumber(val) {
+		return "", numberParseError(val)
+	}
+	return Number(val), nil
+}
+
+// numberArray converts an array value to Number slice.
+// arrayError is the error for values that are not an array.
+func numberArray(val string, arrayError func(string) *Error) ([]Number, error) {
+	json := []byte(val)
+	start := skipSpace(json, 0)
+	// 91 = [
+	if start == len(json) || json[start] != 91 {
+		return nil, arrayError(val)
+	}
+	numbers := make([]Number, 0, 16)
+	var numErr error
+	err := eachChild(json, start, func(label string, start, end int) bool {
+		var num Number
+		num, numErr = toNumber(string(json[start:end]))
+		if numErr != nil {
+			return false
+		}
+		numbers = append(numbers, num)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if numErr != nil {
+		return nil, numErr
+	}
+	return numbers, nil
+}
+
+// rangeError returns an ErrNumberOverflow error if err is a range error of strconv,
+// otherwise it returns parseError.
+func rangeError(err error, val string, typeName string, parseError *Error) *Error {
+	if errors.Is(err, strconv.ErrRange) {
+		return numberOverflowError(val, typeName)
+	}
+	return parseError
+}
+
+func isInteger(val string) bool {
+	if len(val) == 0 {
+		return false
+	}
+	for i := 0; i < len(val); i++ {
+		if !isDigit(val[i]) {
+			return false
+		}
+	}
+	return true
+}
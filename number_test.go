@@ -0,0 +1,102 @@
+package jin
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestNumberGetters(t *testing.T) {
+	json := []byte(`{"max":9223372036854775807,"over":9223372036854775808,"min":-9223372036854775808,"under":-9223372036854775809,` +
+		`"umax":18446744073709551615,"uover":18446744073709551616,"neg":-1,"zero":-0,"quoted":"42",` +
+		`"exp":1e3,"Exp":1.5E+2,"small":2.5e-3,"huge":1e400,"frac":1.5,"text":"abc","bool":true,` +
+		`"big":123456789012345678901234567890}`)
+	pars, err := Parse(json)
+	if err != nil {
+		t.Fatal(err)
+	}
+	type getter struct {
+		name string
+		get  func(path ...string) (interface{}, error)
+		pars func(path ...string) (interface{}, error)
+	}
+	int64s := getter{"Int64",
+		func(path ...string) (interface{}, error) { return GetInt64(json, path...) },
+		func(path ...string) (interface{}, error) { return pars.GetInt64(path...) }}
+	uint64s := getter{"Uint64",
+		func(path ...string) (interface{}, error) { return GetUint64(json, path...) },
+		func(path ...string) (interface{}, error) { return pars.GetUint64(path...) }}
+	floats := getter{"Float",
+		func(path ...string) (interface{}, error) { return GetFloat(json, path...) },
+		func(path ...string) (interface{}, error) { return pars.GetFloat(path...) }}
+	numbers := getter{"Number",
+		func(path ...string) (interface{}, error) { return GetNumber(json, path...) },
+		func(path ...string) (interface{}, error) { return pars.GetNumber(path...) }}
+	bigInts := getter{"BigInt",
+		func(path ...string) (interface{}, error) { return GetBigInt(json, path...) },
+		func(path ...string) (interface{}, error) { return pars.GetBigInt(path...) }}
+	bigFloats := getter{"BigFloat",
+		func(path ...string) (interface{}, error) { return GetBigFloat(json, path...) },
+		func(path ...string) (interface{}, error) { return pars.GetBigFloat(path...) }}
+	tests := []struct {
+		getter getter
+		key    string
+		want   string
+		err    error
+	}{
+		{int64s, "max", "9223372036854775807", nil},
+		{int64s, "over", "", ErrNumberOverflow},
+		{int64s, "min", "-9223372036854775808", nil},
+		{int64s, "under", "", ErrNumberOverflow},
+		{int64s, "zero", "0", nil},
+		{int64s, "quoted", "42", nil},
+		{int64s, "exp", "", ErrIntParse},
+		{int64s, "frac", "", ErrIntParse},
+		{int64s, "text", "", ErrIntParse},
+		{int64s, "missing", "", ErrKeyNotFound},
+
+		{uint64s, "over", "9223372036854775808", nil},
+		{uint64s, "umax", "18446744073709551615", nil},
+		{uint64s, "uover", "", ErrNumberOverflow},
+		{uint64s, "neg", "", ErrNumberOverflow},
+		{uint64s, "min", "", ErrNumberOverflow},
+		{uint64s, "quoted", "42", nil},
+		{uint64s, "exp", "", ErrIntParse},
+		{uint64s, "bool", "", ErrIntParse},
+
+		{floats, "exp", "1000", nil},
+		{floats, "Exp", "150", nil},
+		{floats, "small", "0.0025", nil},
+		{floats, "huge", "", ErrNumberOverflow},
+		{floats, "quoted", "42", nil},
+		{floats, "text", "", ErrFloatParse},
+
+		{numbers, "over", "9223372036854775808", nil},
+		{numbers, "Exp", "1.5E+2", nil},
+		{numbers, "huge", "1e400", nil},
+		{numbers, "quoted", "42", nil},
+		{numbers, "text", "", ErrNumberParse},
+		{numbers, "bool", "", ErrNumberParse},
+
+		{bigInts, "big", "123456789012345678901234567890", nil},
+		{bigInts, "uover", "18446744073709551616", nil},
+		{bigInts, "exp", "", ErrIntParse},
+		{bigInts, "frac", "", ErrIntParse},
+		{bigFloats, "big", "1.2345678901234567890123456789e+29", nil},
+		{bigFloats, "huge", "1e+400", nil},
+		{bigFloats, "text", "", ErrFloatParse},
+	}
+	for _, test := range tests {
+		got, err := test.getter.get(test.key)
+		parsGot, parsErr := test.getter.pars(test.key)
+		if test.err != nil {
+			if !errors.Is(err, test.err) || !errors.Is(parsErr, test.err) {
+				t.Errorf("%v %v: got %v and %v, want %v", test.getter.name, test.key, err, parsErr, test.err)
+			}
+			continue
+		}
+		if err != nil || parsErr != nil || fmt.Sprint(got) != test.want || fmt.Sprint(parsGot) != test.want {
+			t.Errorf("%v %v: got %v %v and %v %v, want %v", test.getter.name, test.key, got, err, parsGot, parsErr, test.want)
+		}
+	}
+}
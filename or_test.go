@@ -0,0 +1,63 @@
+package jin
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestErrorHandler(t *testing.T) {
+	json := []byte(`{"port":"x","timeout":null,"debug":"yes","ratio":1.5}`)
+	var got []string
+	h := ErrorHandler(func(err error, path []string) {
+		got = append(got, strings.Join(path, "."))
+	})
+	if v := h.GetIntOr(json, 80, "port"); v != 80 {
+		t.Errorf("GetIntOr returned %v, want 80", v)
+	}
+	if v := h.GetIntOr(json, 30, "timeout"); v != 30 {
+		t.Errorf("GetIntOr returned %v, want 30", v)
+	}
+	if v := h.GetStringOr(json, "localhost", "host"); v != "localhost" {
+		t.Errorf("GetStringOr returned %v, want localhost", v)
+	}
+	if v := h.GetBoolOr(json, true, "debug"); v != true {
+		t.Errorf("GetBoolOr returned %v, want true", v)
+	}
+	if v := h.GetFloatOr(json, 0, "ratio"); v != 1.5 {
+		t.Errorf("GetFloatOr returned %v, want 1.5", v)
+	}
+	if v := GetAsOrHandler(json, 7, h, "ratio"); v != 7 {
+		t.Errorf("GetAsOrHandler returned %v, want 7", v)
+	}
+	// only conversion errors are handled, missing and null values are not.
+	if want := "port,debug,ratio"; strings.Join(got, ",") != want {
+		t.Errorf("handled %q, want %q", got, want)
+	}
+
+	// package level getters and other handlers do not see the errors.
+	got = nil
+	GetIntOr(json, 80, "port")
+	ErrorHandler(nil).GetIntOr(json, 80, "port")
+	if len(got) != 0 {
+		t.Errorf("handled %q, want nothing", got)
+	}
+
+	pars, err := Parse(json)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pars.GetIntOr(80, "port")
+	if len(got) != 0 {
+		t.Errorf("handled %q without a parser handler", got)
+	}
+	var errs []error
+	pars.SetErrorHandler(func(err error, path []string) { errs = append(errs, err) })
+	pars.GetIntOr(80, "port")
+	pars.GetIntOr(30, "timeout")
+	pars.GetStringOr("x", "missing")
+	ParserGetAsOr(pars, false, "debug")
+	if len(errs) != 2 || !errors.Is(errs[0], ErrIntParse) || !errors.Is(errs[1], ErrBoolParse) {
+		t.Errorf("parser handled %v, want an int and a bool parse error", errs)
+	}
+}
@@ -182,7 +182,14 @@ func Indent(json []byte) []byte {
 }
 
 // ParseArray is a parse function for converting string type arrays to string slices
+// Elements are returned as they are in JSON, only quotation marks are stripped.
 func ParseArray(arr string) []string {
+	return parseArray(arr, false)
+}
+
+// parseArray is the implementation of ParseArray(),
+// decode argument is for decoding escape sequences of string elements.
+func parseArray(arr string, decode bool) []string {
 	if len(arr) < 2 {
 		return []string{}
 	}
@@ -214,8 +221,7 @@ func ParseArray(arr string) []string {
 					level--
 					if curr == 93 {
 						if level == 0 {
-							val := arr[start:i]
-							val = cleanValueString(val)
+							val := cleanArrayElement(arr[start:i], decode)
 							newArray = append(newArray, val)
 							break
 						}
@@ -223,8 +229,7 @@ func ParseArray(arr string) []string {
 				}
 				if level == 1 {
 					if curr == 44 {
-						val := arr[start:i]
-						val = cleanValueString(val)
+						val := cleanArrayElement(arr[start:i], decode)
 						newArray = append(newArray, val)
 						start = i + 1
 						continue
@@ -236,3 +241,10 @@ func ParseArray(arr string) []string {
 	}
 	return nil
 }
+
+func cleanArrayElement(val string, decode bool) string {
+	if decode {
+		return string(cleanString([]byte(val)))
+	}
+	return cleanValueString(val)
+}
@@ -1,6 +1,10 @@
 package jin
 
-import "strconv"
+import (
+	"encoding/base64"
+	"strconv"
+	"time"
+)
 
 // AddKeyValue adds a key-value pair to an object.
 // Path variable must point to an object,
@@ -20,18 +24,18 @@ func (p *Parser) AddKeyValue(key string, newVal []byte, path ...string) error {
 	curr = p.core
 	if lenp == 0 {
 		for _, d := range curr.down {
-			if d.label == key {
+			if keyMatch(d.label, key) {
 				return keyAlreadyExistsError()
 			}
 		}
 		if len(json) >= 2 {
 			if json[0] == 123 && json[len(json)-1] == 125 {
-				newKV := []byte(`,"` + key + `":` + string(newVal))
+				newKV := []byte(`,"` + escape(key) + `":` + string(newVal))
 				if lenv >= 2 {
 					if newVal[0] == 91 || newVal[0] == 123 {
 						newNode := createNode(nil)
 						pCore(newVal, newNode)
-						newNode.label = key
+						newNode.label = escape(key)
 						newNode.value = newVal
 						if len(p.json) == 2 {
 							p.json = replace(p.json, newKV[1:], len(p.json)-1, len(p.json)-1)
@@ -45,7 +49,7 @@ func (p *Parser) AddKeyValue(key string, newVal []byte, path ...string) error {
 				}
 				p.json = replace(p.json, newKV, len(p.json)-1, len(p.json)-1)
 				newNode := createNode(nil)
-				newNode.label = key
+				newNode.label = escape(key)
 				newNode.value = newVal
 				curr.down = append(curr.down, newNode)
 				newNode.up = curr
@@ -53,14 +57,14 @@ func (p *Parser) AddKeyValue(key string, newVal []byte, path ...string) error {
 			}
 			return objectExpectedError()
 		}
-		return badJSONError(0)
+		return badJSONError(json, len(json), "'{'")
 	}
 	curr, err = p.core.walk(path)
 	if err != nil {
 		return err
 	}
 	for _, d := range curr.up.down {
-		if d.label == key {
+		if keyMatch(d.label, key) {
 			return keyAlreadyExistsError()
 		}
 	}
@@ -70,7 +74,7 @@ func (p *Parser) AddKeyValue(key string, newVal []byte, path ...string) error {
 				if newVal[0] == 91 || newVal[0] == 123 {
 					newNode := createNode(nil)
 					pCore(newVal, newNode)
-					newNode.label = key
+					newNode.label = escape(key)
 					newNode.value = newVal
 					curr.down = append(curr.down, newNode)
 					newNode.up = curr
@@ -89,7 +93,7 @@ func (p *Parser) AddKeyValue(key string, newVal []byte, path ...string) error {
 				}
 			}
 			newNode := createNode(nil)
-			newNode.label = key
+			newNode.label = escape(key)
 			newNode.value = newVal
 			curr.down = append(curr.down, newNode)
 			newNode.up = curr
@@ -108,13 +112,17 @@ func (p *Parser) AddKeyValue(key string, newVal []byte, path ...string) error {
 		}
 		return objectExpectedError()
 	}
-	return badJSONError(0)
+	return badJSONError(json, len(json), "'{'")
 }
 
 // Add adds a value to an array.
 // Path variable must point to an array,
 // otherwise it will provide an error message.
+// Last path can be "-" for pointing append position of the array, like JSON Patch.
 func (p *Parser) Add(newVal []byte, path ...string) error {
+	if len(path) != 0 && path[len(path)-1] == "-" {
+		path = path[:len(path)-1]
+	}
 	lenp := len(path)
 	lenv := len(newVal)
 	var curr *node
@@ -162,7 +170,7 @@ func (p *Parser) Add(newVal []byte, path ...string) error {
 			}
 			return arrayExpectedError()
 		}
-		return badJSONError(0)
+		return badJSONError(json, len(json), "'['")
 	}
 	curr, err = p.core.walk(path)
 	if err != nil {
@@ -214,13 +222,19 @@ func (p *Parser) Add(newVal []byte, path ...string) error {
 		}
 		return arrayExpectedError()
 	}
-	return badJSONError(0)
+	return badJSONError(json, len(json), "'['")
 }
 
 // Insert inserts a value to an array.
 // Path variable must point to an array,
 // otherwise it will provide an error message.
+// Negative index counts from the end of the array, -1 inserts before the last element.
+// Last path can be "-" for pointing append position of the array, like JSON Patch.
+// In that case value appended to the array and index is not used.
 func (p *Parser) Insert(newIndex int, newVal []byte, path ...string) error {
+	if len(path) != 0 && path[len(path)-1] == "-" {
+		return p.Add(newVal, path[:len(path)-1]...)
+	}
 	lenp := len(path)
 	lenv := len(newVal)
 	var curr *node
@@ -277,7 +291,7 @@ func (p *Parser) Insert(newIndex int, newVal []byte, path ...string) error {
 			}
 			return arrayExpectedError()
 		}
-		return badJSONError(0)
+		return badJSONError(json, len(json), "'['")
 	}
 	curr, err = p.core.walk(path)
 	if err != nil {
@@ -333,11 +347,12 @@ func (p *Parser) Insert(newIndex int, newVal []byte, path ...string) error {
 		}
 		return arrayExpectedError()
 	}
-	return badJSONError(0)
+	return badJSONError(json, len(json), "'['")
 }
 
 // AddKeyValueString is a variation of AddKeyValue() func.
 // Type of new value must be a string.
+// Value is always written as a JSON string escaped like Quote() does, so "42" is a string, not a number.
 func (p *Parser) AddKeyValueString(key, value string, path ...string) error {
 	if len(value) == 0 {
 		return nullNewValueError()
@@ -345,7 +360,7 @@ func (p *Parser) AddKeyValueString(key, value string, path ...string) error {
 	if len(key) == 0 {
 		return nullKeyError()
 	}
-	return p.AddKeyValue(key, []byte(formatType(value)), path...)
+	return p.AddKeyValue(key, []byte(Quote(value)), path...)
 }
 
 // AddKeyValueInt is a variation of AddKeyValue() func.
@@ -363,7 +378,11 @@ func (p *Parser) AddKeyValueFloat(key string, value float64, path ...string) err
 	if len(key) == 0 {
 		return nullKeyError()
 	}
-	return p.AddKeyValue(key, []byte(strconv.FormatFloat(value, 'e', -1, 64)), path...)
+	num, err := FormatFloat(value, -1)
+	if err != nil {
+		return err
+	}
+	return p.AddKeyValue(key, []byte(num), path...)
 }
 
 // AddKeyValueBool is a variation of AddKeyValue() func.
@@ -378,13 +397,41 @@ func (p *Parser) AddKeyValueBool(key string, value bool, path ...string) error {
 	return p.AddKeyValue(key, []byte("false"), path...)
 }
 
+// AddKeyValueNull is a variation of AddKeyValue() func.
+// New value is null.
+func (p *Parser) AddKeyValueNull(key string, path ...string) error {
+	if len(key) == 0 {
+		return nullKeyError()
+	}
+	return p.AddKeyValue(key, []byte("null"), path...)
+}
+
+// AddKeyValueBytes is a variation of AddKeyValue() func.
+// New value is written as a base64 string like SetBytes() does.
+func (p *Parser) AddKeyValueBytes(key string, value []byte, enc *base64.Encoding, path ...string) error {
+	if len(key) == 0 {
+		return nullKeyError()
+	}
+	return p.AddKeyValue(key, encodeBase64(value, enc), path...)
+}
+
+// AddKeyValueTime is a variation of AddKeyValue() func.
+// New value is written with layout like SetTime() does.
+func (p *Parser) AddKeyValueTime(key string, value time.Time, layout string, path ...string) error {
+	if len(key) == 0 {
+		return nullKeyError()
+	}
+	return p.AddKeyValue(key, formatTime(value, layout), path...)
+}
+
 // AddString is a variation of Add() func.
 // Type of new value must be an string.
+// Value is always written as a JSON string escaped like Quote() does, so "42" is a string, not a number.
 func (p *Parser) AddString(value string, path ...string) error {
 	if len(value) == 0 {
 		return nullNewValueError()
 	}
-	return p.Add([]byte(formatType(value)), path...)
+	return p.Add([]byte(Quote(value)), path...)
 }
 
 // AddInt is a variation of Add() func.
@@ -396,7 +443,11 @@ func (p *Parser) AddInt(value int, path ...string) error {
 // AddFloat is a variation of Add() func.
 // Type of new value must be an float64.
 func (p *Parser) AddFloat(value float64, path ...string) error {
-	return p.Add([]byte(strconv.FormatFloat(value, 'e', -1, 64)), path...)
+	num, err := FormatFloat(value, -1)
+	if err != nil {
+		return err
+	}
+	return p.Add([]byte(num), path...)
 }
 
 // AddBool is a variation of Add() func.
@@ -408,44 +459,49 @@ func (p *Parser) AddBool(value bool, path ...string) error {
 	return p.Add([]byte("false"), path...)
 }
 
+// AddNull is a variation of Add() func.
+// New value is null.
+func (p *Parser) AddNull(path ...string) error {
+	return p.Add([]byte("null"), path...)
+}
+
 // InsertString is a variation of Insert() func.
 // Type of new value must be an string.
+// Value is always written as a JSON string escaped like Quote() does, so "42" is a string, not a number.
 func (p *Parser) InsertString(index int, value string, path ...string) error {
 	if len(value) == 0 {
 		return nullNewValueError()
 	}
-	if index < 0 {
-		return indexOutOfRangeError()
-	}
-	return p.Insert(index, []byte(formatType(value)), path...)
+	return p.Insert(index, []byte(Quote(value)), path...)
 }
 
 // InsertInt is a variation of Insert() func.
 // Type of new value must be an integer.
 func (p *Parser) InsertInt(index, value int, path ...string) error {
-	if index < 0 {
-		return indexOutOfRangeError()
-	}
 	return p.Insert(index, []byte(strconv.Itoa(value)), path...)
 }
 
 // InsertFloat is a variation of Insert() func.
 // Type of new value must be an float64.
 func (p *Parser) InsertFloat(index int, value float64, path ...string) error {
-	if index < 0 {
-		return indexOutOfRangeError()
+	num, err := FormatFloat(value, -1)
+	if err != nil {
+		return err
 	}
-	return p.Insert(index, []byte(strconv.FormatFloat(value, 'e', -1, 64)), path...)
+	return p.Insert(index, []byte(num), path...)
 }
 
 // InsertBool is a variation of Insert() func.
 // Type of new value must be an boolean.
 func (p *Parser) InsertBool(index int, value bool, path ...string) error {
-	if index < 0 {
-		return indexOutOfRangeError()
-	}
 	if value {
 		return p.Insert(index, []byte("true"), path...)
 	}
 	return p.Insert(index, []byte("false"), path...)
 }
+
+// InsertNull is a variation of Insert() func.
+// New value is null.
+func (p *Parser) InsertNull(index int, path ...string) error {
+	return p.Insert(index, []byte("null"), path...)
+}
@@ -0,0 +1,26 @@
+package jin
+
+// SetCoercion sets the type conversion policy of typed getters of the Parser,
+// like GetInt(), GetBool() or GetFloatArray(). nil restores the default behavior.
+//
+//	pars.SetCoercion(&jin.LooseCoercion)
+func (p *Parser) SetCoercion(c *Coercion) {
+	if c == nil {
+		p.coercion = nil
+		return
+	}
+	policy := *c
+	p.coercion = &policy
+}
+
+// rawValue returns the value that path has pointed, with quotation marks of strings.
+func (p *Parser) rawValue(path []string) ([]byte, error) {
+	if len(path) == 0 {
+		return p.json, nil
+	}
+	curr, err := p.core.walk(path)
+	if err != nil {
+		return nil, err
+	}
+	return curr.value, nil
+}
@@ -0,0 +1,81 @@
+package jin
+
+// Go methods can not have type parameters,
+// so generic functions of Parser take the Parser as their first parameter.
+
+// ParserGetAs is the Parser equivalent of GetAs().
+//
+//	id, err := jin.ParserGetAs[int64](pars, "user", "id")
+func ParserGetAs[T any](p *Parser, path ...string) (T, error) {
+	var value T
+	if ok, err := getTyped(p, &value, path); ok {
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+		return value, nil
+	}
+	json := p.json
+	if len(path) != 0 {
+		curr, err := p.core.walk(path)
+		if err != nil {
+			return value, err
+		}
+		json = curr.value
+	}
+	if err := decodeAt(json, skipSpace(json, 0), &value); err != nil {
+		var zero T
+		return zero, err
+	}
+	return value, nil
+}
+
+// ParserGetAsOr is the Parser equivalent of GetAsOr(), errors are given to the ErrorHandler of the Parser.
+func ParserGetAsOr[T any](p *Parser, def T, path ...string) T {
+	json := p.json
+	if len(path) != 0 {
+		curr, err := p.core.walk(path)
+		if err != nil {
+			p.errorHandler.handle(err, path)
+			return def
+		}
+		json = curr.value
+	}
+	start := skipSpace(json, 0)
+	if isNull(json[start:]) {
+		return def
+	}
+	var value T
+	if err := decodeAt(json, start, &value); err != nil {
+		p.errorHandler.handle(err, path)
+		return def
+	}
+	return value
+}
+
+// ParserSetAs is the Parser equivalent of SetAs().
+func ParserSetAs[T any](p *Parser, newValue T, path ...string) error {
+	val, err := Marshal(newValue)
+	if err != nil {
+		return err
+	}
+	return p.Set(val, path...)
+}
+
+// ParserAddAs is the Parser equivalent of AddAs().
+func ParserAddAs[T any](p *Parser, value T, path ...string) error {
+	val, err := Marshal(value)
+	if err != nil {
+		return err
+	}
+	return p.Add(val, path...)
+}
+
+// ParserAddKeyValueAs is the Parser equivalent of AddKeyValueAs().
+func ParserAddKeyValueAs[T any](p *Parser, key string, value T, path ...string) error {
+	val, err := Marshal(value)
+	if err != nil {
+		return err
+	}
+	return p.AddKeyValue(key, val, path...)
+}
@@ -1,6 +1,10 @@
 package jin
 
-import "strconv"
+import (
+	"math/big"
+	"strconv"
+	"time"
+)
 
 // Get returns the value that path has pointed.
 // It stripes quotation marks from string values.
@@ -42,7 +46,23 @@ func (p *Parser) GetNew(path ...string) []byte {
 
 // GetString is a variation of Get() func.
 // GetString returns the value that path has pointed as string.
+// Escape sequences of string values are decoded, like '\n', '\"' and '\u00e9'.
+// Use GetStringRaw() for the value as it is in JSON.
 func (p *Parser) GetString(path ...string) (string, error) {
+	if len(path) == 0 {
+		return string(p.json), nil
+	}
+	curr, err := p.core.walk(path)
+	if err != nil {
+		return "", err
+	}
+	return string(cleanString(curr.value)), nil
+}
+
+// GetStringRaw is a variation of Get() func.
+// GetStringRaw returns the value that path has pointed as string,
+// escape sequences are not decoded.
+func (p *Parser) GetStringRaw(path ...string) (string, error) {
 	val, err := p.Get(path...)
 	if err != nil {
 		return "", err
@@ -54,13 +74,25 @@ func (p *Parser) GetString(path ...string) (string, error) {
 // GetInt returns the value that path has pointed as integer.
 // returns an error message if the value to be returned cannot be converted to an integer
 func (p *Parser) GetInt(path ...string) (int, error) {
+	if p.coercion != nil {
+		raw, err := p.rawValue(path)
+		if err != nil {
+			return -1, err
+		}
+		val, quoted := scalar(raw)
+		num, err := p.coercion.toInt(val, quoted, strconv.IntSize, "int")
+		if err != nil {
+			return -1, err
+		}
+		return int(num), nil
+	}
 	val, err := p.GetString(path...)
 	if err != nil {
 		return -1, err
 	}
 	intVal, err := strconv.Atoi(val)
 	if err != nil {
-		return -1, intParseError(val)
+		return -1, rangeError(err, val, "int", intParseError(val))
 	}
 	return intVal, nil
 }
@@ -69,13 +101,21 @@ func (p *Parser) GetInt(path ...string) (int, error) {
 // GetFloat returns the value that path has pointed as float.
 // returns an error message if the value to be returned cannot be converted to an float
 func (p *Parser) GetFloat(path ...string) (float64, error) {
+	if p.coercion != nil {
+		raw, err := p.rawValue(path)
+		if err != nil {
+			return -1, err
+		}
+		val, quoted := scalar(raw)
+		return p.coercion.toFloat(val, quoted)
+	}
 	val, err := p.GetString(path...)
 	if err != nil {
 		return -1, err
 	}
 	floatVal, err := strconv.ParseFloat(val, 64)
 	if err != nil {
-		return -1, floatParseError(val)
+		return -1, rangeError(err, val, "float64", floatParseError(val))
 	}
 	return floatVal, nil
 }
@@ -84,6 +124,14 @@ func (p *Parser) GetFloat(path ...string) (float64, error) {
 // GetBool returns the value that path has pointed as boolean.
 // returns an error message if the value to be returned cannot be converted to an boolean
 func (p *Parser) GetBool(path ...string) (bool, error) {
+	if p.coercion != nil {
+		raw, err := p.rawValue(path)
+		if err != nil {
+			return false, err
+		}
+		val, quoted := scalar(raw)
+		return p.coercion.toBool(val, quoted)
+	}
 	val, err := p.GetString(path...)
 	if err != nil {
 		return false, err
@@ -97,6 +145,206 @@ func (p *Parser) GetBool(path ...string) (bool, error) {
 	return false, boolParseError(val)
 }
 
+// GetNumber is a variation of Get() func.
+// GetNumber returns the value that path has pointed as Number, in its exact lexical form.
+// returns an error message if the value to be returned is not a number.
+func (p *Parser) GetNumber(path ...string) (Number, error) {
+	val, err := p.GetString(path...)
+	if err != nil {
+		return "", err
+	}
+	return toNumber(val)
+}
+
+// GetInt64 is a variation of Get() func.
+// GetInt64 returns the value that path has pointed as int64.
+// returns an error message if the value to be returned cannot be converted to an int64,
+// error code is ErrNumberOverflow if the value is out of int64 range.
+func (p *Parser) GetInt64(path ...string) (int64, error) {
+	if p.coercion != nil {
+		raw, err := p.rawValue(path)
+		if err != nil {
+			return -1, err
+		}
+		val, quoted := scalar(raw)
+		return p.coercion.toInt(val, quoted, 64, "int64")
+	}
+	val, err := p.GetString(path...)
+	if err != nil {
+		return -1, err
+	}
+	return Number(val).Int64()
+}
+
+// GetUint64 is a variation of Get() func.
+// GetUint64 returns the value that path has pointed as uint64.
+// returns an error message if the value to be returned cannot be converted to an uint64,
+// error code is ErrNumberOverflow if the value is out of uint64 range.
+func (p *Parser) GetUint64(path ...string) (uint64, error) {
+	if p.coercion != nil {
+		raw, err := p.rawValue(path)
+		if err != nil {
+			return 0, err
+		}
+		val, quoted := scalar(raw)
+		return p.coercion.toUint(val, quoted)
+	}
+	val, err := p.GetString(path...)
+	if err != nil {
+		return 0, err
+	}
+	return Number(val).Uint64()
+}
+
+// GetBigInt is a variation of Get() func.
+// GetBigInt returns the value that path has pointed as *big.Int, it never overflows.
+// returns an error message if the value to be returned cannot be converted to an integer.
+func (p *Parser) GetBigInt(path ...string) (*big.Int, error) {
+	val, err := p.GetString(path...)
+	if err != nil {
+		return nil, err
+	}
+	return Number(val).BigInt()
+}
+
+// GetBigFloat is a variation of Get() func.
+// GetBigFloat returns the value that path has pointed as *big.Float,
+// precision is enough to hold all decimal digits of the value.
+// returns an error message if the value to be returned cannot be converted to a float.
+func (p *Parser) GetBigFloat(path ...string) (*big.Float, error) {
+	val, err := p.GetString(path...)
+	if err != nil {
+		return nil, err
+	}
+	return Number(val).BigFloat()
+}
+
+// SetErrorHandler sets the ErrorHandler of default value getters of the Parser,
+// like GetIntOr() or GetStringOr(). nil restores the default behavior, errors are ignored.
+func (p *Parser) SetErrorHandler(h ErrorHandler) {
+	p.errorHandler = h
+}
+
+// GetStringOr is a variation of GetString() func.
+// It returns def if path does not exist, value is null or there is an error.
+// Errors other than missing values are given to the ErrorHandler of the Parser.
+func (p *Parser) GetStringOr(def string, path ...string) string {
+	val, err := p.GetString(path...)
+	if err != nil {
+		p.errorHandler.handle(err, path)
+		return def
+	}
+	if val == "null" {
+		if null, _ := p.IsNull(path...); null {
+			return def
+		}
+	}
+	return val
+}
+
+// GetIntOr is a variation of GetInt() func.
+// It returns def if path does not exist, value is null or there is an error.
+// Errors other than missing values are given to the ErrorHandler of the Parser.
+func (p *Parser) GetIntOr(def int, path ...string) int {
+	val, err := p.GetInt(path...)
+	if err != nil {
+		p.orError(err, path)
+		return def
+	}
+	return val
+}
+
+// GetFloatOr is a variation of GetFloat() func.
+// It returns def if path does not exist, value is null or there is an error.
+// Errors other than missing values are given to the ErrorHandler of the Parser.
+func (p *Parser) GetFloatOr(def float64, path ...string) float64 {
+	val, err := p.GetFloat(path...)
+	if err != nil {
+		p.orError(err, path)
+		return def
+	}
+	return val
+}
+
+// GetBoolOr is a variation of GetBool() func.
+// It returns def if path does not exist, value is null or there is an error.
+// Errors other than missing values are given to the ErrorHandler of the Parser.
+func (p *Parser) GetBoolOr(def bool, path ...string) bool {
+	val, err := p.GetBool(path...)
+	if err != nil {
+		p.orError(err, path)
+		return def
+	}
+	return val
+}
+
+// orError gives err to the ErrorHandler of the Parser, unless the value is null.
+func (p *Parser) orError(err error, path []string) {
+	if p.errorHandler == nil {
+		return
+	}
+	if null, _ := p.IsNull(path...); !null {
+		p.errorHandler.handle(err, path)
+	}
+}
+
+// GetTime is a variation of Get() func.
+// GetTime returns the value that path has pointed as time.Time, parsed with layout.
+// layout can be any layout of time.Parse(), empty layout is time.RFC3339Nano,
+// LayoutUnix and LayoutUnixMilli are for Unix timestamps given as numbers, they are returned in UTC.
+//
+//	created, err := pars.GetTime(time.RFC3339, "created_at")
+//	updated, err := pars.GetTime(jin.LayoutUnixMilli, "updated_at")
+func (p *Parser) GetTime(layout string, path ...string) (time.Time, error) {
+	val, err := p.GetString(path...)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return parseTime(val, layout)
+}
+
+// GetDuration is a variation of Get() func.
+// GetDuration returns the value that path has pointed as time.Duration,
+// value must be a Go duration string like "1h30m" or "250ms".
+func (p *Parser) GetDuration(path ...string) (time.Duration, error) {
+	val, err := p.GetString(path...)
+	if err != nil {
+		return 0, err
+	}
+	return parseDuration(val)
+}
+
+// GetBytes is a variation of Get() func.
+// GetBytes returns the value that path has pointed as binary data decoded from base64.
+// Standard and URL-safe alphabets are accepted, with or without padding.
+func (p *Parser) GetBytes(path ...string) ([]byte, error) {
+	val, err := p.GetString(path...)
+	if err != nil {
+		return nil, err
+	}
+	return decodeBase64(val)
+}
+
+// GetMany is a variation of GetMany() func, values and errs are in the order of paths.
+// Parser has already walked the document, so every path is found in the node tree.
+func (p *Parser) GetMany(paths [][]string) ([][]byte, []error) {
+	values := make([][]byte, len(paths))
+	errs := make([]error, len(paths))
+	p.GetManyFunc(paths, func(index int, value []byte, err error) {
+		values[index] = value
+		errs[index] = err
+	})
+	return values, errs
+}
+
+// GetManyFunc is a variation of GetManyFunc() func, callback is called in the order of paths.
+func (p *Parser) GetManyFunc(paths [][]string, callback func(index int, value []byte, err error)) {
+	for i, path := range paths {
+		value, err := p.Get(path...)
+		callback(i, value, err)
+	}
+}
+
 // GetStringArray is a variation of Get() func.
 // GetStringArray returns the value that path has pointed as string slice.
 // returns an error message if the value to be returned cannot be converted to an string slice.
@@ -110,7 +358,7 @@ func (p *Parser) GetStringArray(path ...string) ([]string, error) {
 		return nil, stringArrayParseError(val)
 	}
 	if val[0] == 91 && val[lena-1] == 93 {
-		arr := ParseArray(val)
+		arr := parseArray(val, true)
 		if arr == nil {
 			return nil, stringArrayParseError(val)
 		}
@@ -123,6 +371,13 @@ func (p *Parser) GetStringArray(path ...string) ([]string, error) {
 // GetIntArray returns the value that path has pointed as integer slice.
 // returns an error message if the value to be returned cannot be converted to an integer slice.
 func (p *Parser) GetIntArray(path ...string) ([]int, error) {
+	if p.coercion != nil {
+		raw, err := p.rawValue(path)
+		if err != nil {
+			return nil, err
+		}
+		return p.coercion.intArray(raw)
+	}
 	val, err := p.GetString(path...)
 	if err != nil {
 		return nil, err
@@ -159,7 +414,7 @@ func (p *Parser) GetIntArray(path ...string) ([]int, error) {
 							element := val[start:i]
 							num, err := strconv.Atoi(cleanValueString(element))
 							if err != nil {
-								return nil, intParseError(cleanValueString(element))
+								return nil, rangeError(err, cleanValueString(element), "int", intParseError(cleanValueString(element)))
 							}
 							newArray = append(newArray, num)
 							break
@@ -171,7 +426,7 @@ func (p *Parser) GetIntArray(path ...string) ([]int, error) {
 						element := val[start:i]
 						num, err := strconv.Atoi(cleanValueString(element))
 						if err != nil {
-							return nil, intParseError(cleanValueString(element))
+							return nil, rangeError(err, cleanValueString(element), "int", intParseError(cleanValueString(element)))
 						}
 						newArray = append(newArray, num)
 						start = i + 1
@@ -189,6 +444,13 @@ func (p *Parser) GetIntArray(path ...string) ([]int, error) {
 // GetFloatArray returns the value that path has pointed as float slice.
 // returns an error message if the value to be returned cannot be converted to an float slice.
 func (p *Parser) GetFloatArray(path ...string) ([]float64, error) {
+	if p.coercion != nil {
+		raw, err := p.rawValue(path)
+		if err != nil {
+			return nil, err
+		}
+		return p.coercion.floatArray(raw)
+	}
 	val, err := p.GetString(path...)
 	if err != nil {
 		return nil, err
@@ -225,7 +487,7 @@ func (p *Parser) GetFloatArray(path ...string) ([]float64, error) {
 							element := val[start:i]
 							num, err := strconv.ParseFloat(cleanValueString(element), 64)
 							if err != nil {
-								return nil, floatParseError(cleanValueString(element))
+								return nil, rangeError(err, cleanValueString(element), "float64", floatParseError(cleanValueString(element)))
 							}
 							newArray = append(newArray, num)
 							break
@@ -237,7 +499,7 @@ func (p *Parser) GetFloatArray(path ...string) ([]float64, error) {
 						element := val[start:i]
 						num, err := strconv.ParseFloat(cleanValueString(element), 64)
 						if err != nil {
-							return nil, floatParseError(cleanValueString(element))
+							return nil, rangeError(err, cleanValueString(element), "float64", floatParseError(cleanValueString(element)))
 						}
 						newArray = append(newArray, num)
 						start = i + 1
@@ -255,6 +517,13 @@ func (p *Parser) GetFloatArray(path ...string) ([]float64, error) {
 // GetBoolArray returns the value that path has pointed as boolean slice.
 // returns an error message if the value to be returned cannot be converted to an boolean slice.
 func (p *Parser) GetBoolArray(path ...string) ([]bool, error) {
+	if p.coercion != nil {
+		raw, err := p.rawValue(path)
+		if err != nil {
+			return nil, err
+		}
+		return p.coercion.boolArray(raw)
+	}
 	val, err := p.GetString(path...)
 	if err != nil {
 		return nil, err
@@ -326,3 +595,74 @@ func (p *Parser) GetBoolArray(path ...string) ([]bool, error) {
 	}
 	return nil, boolArrayParseError(val)
 }
+
+// GetNumberArray is a variation of Get() func.
+// GetNumberArray returns the value that path has pointed as Number slice.
+// returns an error message if the value to be returned cannot be converted to a Number slice.
+func (p *Parser) GetNumberArray(path ...string) ([]Number, error) {
+	val, err := p.GetString(path...)
+	if err != nil {
+		return nil, err
+	}
+	return numberArray(val, numberParseError)
+}
+
+// GetInt64Array is a variation of Get() func.
+// GetInt64Array returns the value that path has pointed as int64 slice.
+// returns an error message if the value to be returned cannot be converted to an int64 slice,
+// error code is ErrNumberOverflow if an element is out of int64 range.
+func (p *Parser) GetInt64Array(path ...string) ([]int64, error) {
+	if p.coercion != nil {
+		raw, err := p.rawValue(path)
+		if err != nil {
+			return nil, err
+		}
+		return p.coercion.int64Array(raw)
+	}
+	val, err := p.GetString(path...)
+	if err != nil {
+		return nil, err
+	}
+	numbers, err := numberArray(val, intArrayParseError)
+	if err != nil {
+		return nil, err
+	}
+	newArray := make([]int64, len(numbers))
+	for i, n := range numbers {
+		newArray[i], err = n.Int64()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return newArray, nil
+}
+
+// GetUint64Array is a variation of Get() func.
+// GetUint64Array returns the value that path has pointed as uint64 slice.
+// returns an error message if the value to be returned cannot be converted to an uint64 slice,
+// error code is ErrNumberOverflow if an element is out of uint64 range.
+func (p *Parser) GetUint64Array(path ...string) ([]uint64, error) {
+	if p.coercion != nil {
+		raw, err := p.rawValue(path)
+		if err != nil {
+			return nil, err
+		}
+		return p.coercion.uint64Array(raw)
+	}
+	val, err := p.GetString(path...)
+	if err != nil {
+		return nil, err
+	}
+	numbers, err := numberArray(val, intArrayParseError)
+	if err != nil {
+		return nil, err
+	}
+	newArray := make([]uint64, len(numbers))
+	for i, n := range numbers {
+		newArray[i], err = n.Uint64()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return newArray, nil
+}
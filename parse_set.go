@@ -1,6 +1,10 @@
 package jin
 
-import "strconv"
+import (
+	"encoding/base64"
+	"strconv"
+	"time"
+)
 
 // Set sets the value that path has pointed.
 // Path can point anything, a key-value pair, a value, an array, an object.
@@ -55,11 +59,12 @@ func (p *Parser) Set(newVal []byte, path ...string) error {
 
 // SetString is a variation of Set() func.
 // SetString takes the set value as string.
+// Value is always written as a JSON string escaped like Quote() does, so "42" is a string, not a number.
 func (p *Parser) SetString(newValue string, path ...string) error {
-	if newValue[0] != 34 && newValue[len(newValue)-1] != 34 {
-		return p.Set([]byte(`"`+newValue+`"`), path...)
+	if len(newValue) == 0 {
+		return nullNewValueError()
 	}
-	return p.Set([]byte(newValue), path...)
+	return p.Set([]byte(Quote(newValue)), path...)
 }
 
 // SetInt is a variation of Set() func.
@@ -71,7 +76,11 @@ func (p *Parser) SetInt(newValue int, path ...string) error {
 // SetFloat is a variation of Set() func.
 // SetFloat takes the set value as float64.
 func (p *Parser) SetFloat(newValue float64, path ...string) error {
-	return p.Set([]byte(strconv.FormatFloat(newValue, 'e', -1, 64)), path...)
+	num, err := FormatFloat(newValue, -1)
+	if err != nil {
+		return err
+	}
+	return p.Set([]byte(num), path...)
 }
 
 // SetBool is a variation of Set() func.
@@ -83,6 +92,26 @@ func (p *Parser) SetBool(newValue bool, path ...string) error {
 	return p.Set([]byte("false"), path...)
 }
 
+// SetNull is a variation of Set() func.
+// SetNull sets the value that path has pointed to null.
+func (p *Parser) SetNull(path ...string) error {
+	return p.Set([]byte("null"), path...)
+}
+
+// SetTime is a variation of Set() func.
+// SetTime writes newValue with layout like GetTime() reads it,
+// Unix layouts are written as numbers, other layouts as strings.
+func (p *Parser) SetTime(newValue time.Time, layout string, path ...string) error {
+	return p.Set(formatTime(newValue, layout), path...)
+}
+
+// SetBytes is a variation of Set() func.
+// SetBytes writes newValue as a base64 string encoded with enc,
+// like base64.URLEncoding or base64.RawStdEncoding. nil enc is base64.StdEncoding.
+func (p *Parser) SetBytes(newValue []byte, enc *base64.Encoding, path ...string) error {
+	return p.Set(encodeBase64(newValue, enc), path...)
+}
+
 // SetKey sets the key value of key-value pair that path has pointed.
 // Path must point to an object.
 // otherwise it will provide an error message.
@@ -103,11 +132,11 @@ func (p *Parser) SetKey(newKey string, path ...string) error {
 		return err
 	}
 	for _, d := range curr.up.down {
-		if d.label == newKey {
+		if keyMatch(d.label, newKey) {
 			return keyAlreadyExistsError()
 		}
 	}
-	curr.label = newKey
+	curr.label = escape(newKey)
 	p.json, _ = SetKey(p.json, newKey, path...)
 	for i := 0; i < lenp-1; i++ {
 		curr = curr.up
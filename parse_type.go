@@ -0,0 +1,28 @@
+package jin
+
+// IsNull is a type control function.
+// If path points to a null value it will return true, otherwise it will return false.
+// String values like "null" are not null.
+func (p *Parser) IsNull(path ...string) (bool, error) {
+	curr, err := p.core.walk(path)
+	if err != nil {
+		return false, err
+	}
+	return string(trim(curr.value)) == "null", nil
+}
+
+// GetKind is a type return function.
+// Unlike GetType() it distinguishes strings, numbers, booleans and null values.
+// Kind is determined by the first character of the value, so "true" is a string and true is a bool.
+func (p *Parser) GetKind(path ...string) (Kind, error) {
+	curr, err := p.core.walk(path)
+	if err != nil {
+		return KindInvalid, err
+	}
+	value := trim(curr.value)
+	kind := kindOf(value)
+	if kind == KindInvalid {
+		return KindInvalid, badJSONError(value, 0, "value")
+	}
+	return kind, nil
+}
@@ -18,6 +18,10 @@ type node struct {
 type Parser struct {
 	core *node
 	json []byte
+	// coercion is the conversion policy of typed getters, nil for the default behavior.
+	coercion *Coercion
+	// errorHandler receives the errors of default value getters, nil ignores them.
+	errorHandler ErrorHandler
 }
 
 func createNode(up *node) *node {
@@ -74,7 +78,7 @@ func Parse(json []byte) (*Parser, error) {
 		return nil, err
 	}
 	if core.down == nil {
-		return nil, badJSONError(0)
+		return nil, badJSONError(json, skipSpace(json, 0), "'{' or '['")
 	}
 	core = core.down[0]
 	pars := Parser{core: core, json: json}
@@ -89,7 +93,7 @@ func ParseNew(json []byte) (*Parser, error) {
 		return nil, err
 	}
 	if core.down == nil {
-		return nil, badJSONError(0)
+		return nil, badJSONError(json, skipSpace(json, 0), "'{' or '['")
 	}
 	core = core.down[0]
 	pars := Parser{core: core}
@@ -98,6 +102,12 @@ func ParseNew(json []byte) (*Parser, error) {
 
 func (n *node) insert(up *node, index int) error {
 	lend := len(up.down)
+	if index < 0 {
+		index += lend
+		if index < 0 {
+			return indexOutOfRangeError()
+		}
+	}
 	if lend != 0 {
 		if lend-1 < index {
 			return indexOutOfRangeError()
@@ -125,12 +135,26 @@ func (n *node) deAttach() {
 func (n *node) walk(path []string) (*node, error) {
 	for _, p := range path {
 		for _, d := range n.down {
-			if d.label == p {
+			if keyMatch(d.label, p) {
 				n = d
 				goto cont
 			}
 		}
-		return nil, keyNotFoundError()
+		// negative indexes counts from the end of the array.
+		if len(p) > 1 && p[0] == 45 && len(n.value) > 0 && n.value[0] == 91 {
+			index, err := strconv.Atoi(p)
+			if err == nil && len(n.down)+index >= 0 {
+				label := strconv.Itoa(len(n.down) + index)
+				for _, d := range n.down {
+					if d.label == label {
+						n = d
+						goto cont
+					}
+				}
+			}
+			return nil, indexOutOfRangeError().at(-1, p)
+		}
+		return nil, keyNotFoundError().at(-1, p)
 	cont:
 		continue
 	}
@@ -0,0 +1,391 @@
+package jin
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// segment kinds of a compiled path expression.
+const (
+	segmentKey = iota
+	segmentIndex
+	segmentWildcard
+	segmentSlice
+	segmentFilter
+)
+
+// pathSegment is one step of a compiled path expression.
+type pathSegment struct {
+	kind int
+	// key or index text, exactly as it will be given to core().
+	value string
+	// recursive is true for segments that written after '..'
+	recursive bool
+	// slice bounds, hasStart and hasEnd reports whether the bound is written.
+	start    int
+	end      int
+	step     int
+	hasStart bool
+	hasEnd   bool
+	// compiled filter expression, used by segmentFilter.
+	filter *filterNode
+	// offset of the segment in expression, for error reporting.
+	at int
+}
+
+// ParsePath compiles a JSONPath like expression to path segments.
+// Returned segments can be given to any function that takes 'path ...string'.
+//
+//	$.repo.name[1]        -> ["repo", "name", "1"]
+//	repo.name[1]          -> ["repo", "name", "1"]
+//	$['weird.key'].value  -> ["weird.key", "value"]
+//	$["say \"hi\""]       -> ["say \"hi\""]
+//
+// Root symbol '$' is optional. Single and double quoted segments supports
+// backslash escapes (\\ \' \" \/ \b \f \n \r \t \uXXXX),
+// dot notation segments supports escaping of '.', '[' and '\' with a backslash.
+// Indexes are written without leading zeros, negative indexes counts from the end of the array.
+// Wildcards, recursive descents and slices are not allowed, they can only be used with Query().
+func ParsePath(expr string) ([]string, error) {
+	segments, err := parsePath(expr)
+	if err != nil {
+		return nil, err
+	}
+	path := make([]string, len(segments))
+	for i, s := range segments {
+		if s.recursive || (s.kind != segmentKey && s.kind != segmentIndex) {
+			return nil, badPathError(expr, s.at)
+		}
+		path[i] = s.value
+	}
+	return path, nil
+}
+
+// MustParsePath is same function with ParsePath,
+// except it panics if expression cannot be compiled.
+// It is useful for package level path variables.
+func MustParsePath(expr string) []string {
+	path, err := ParsePath(expr)
+	if err != nil {
+		panic(err)
+	}
+	return path
+}
+
+// FormatPath is the reverse of ParsePath.
+// It creates a JSONPath like expression from path segments.
+// Numeric segments formatted as indexes, keys that contains special
+// characters formatted with bracket notation.
+func FormatPath(path ...string) string {
+	var sb strings.Builder
+	sb.WriteByte(36)
+	for _, p := range path {
+		if isIndex(p) {
+			sb.WriteByte(91)
+			sb.WriteString(p)
+			sb.WriteByte(93)
+			continue
+		}
+		if isPlainKey(p) {
+			sb.WriteByte(46)
+			sb.WriteString(p)
+			continue
+		}
+		sb.WriteString("['")
+		for i := 0; i < len(p); i++ {
+			// 39 = ', 92 = \
+			if p[i] == 39 || p[i] == 92 {
+				sb.WriteByte(92)
+			}
+			sb.WriteByte(p[i])
+		}
+		sb.WriteString("']")
+	}
+	return sb.String()
+}
+
+func parsePath(expr string) ([]pathSegment, error) {
+	segments := make([]pathSegment, 0, 8)
+	offset := 0
+	lene := len(expr)
+	// 36 = $, root symbol is optional.
+	if lene > 0 && expr[0] == 36 {
+		offset++
+	} else {
+		// first key can be written without a leading dot. 'repo.name'
+		if lene > 0 && expr[0] != 91 && expr[0] != 46 {
+			segment, next, err := parseDotSegment(expr, offset)
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, segment)
+			offset = next
+		}
+	}
+	for offset < lene {
+		curr := expr[offset]
+		switch curr {
+		// 46 = .
+		case 46:
+			offset++
+			recursive := false
+			// '..' is recursive descent.
+			if offset < lene && expr[offset] == 46 {
+				recursive = true
+				offset++
+			}
+			if offset >= lene {
+				return nil, badPathError(expr, offset)
+			}
+			var segment pathSegment
+			var next int
+			var err error
+			if recursive && expr[offset] == 91 {
+				segment, next, err = parseBracket(expr, offset)
+			} else {
+				segment, next, err = parseDotSegment(expr, offset)
+			}
+			if err != nil {
+				return nil, err
+			}
+			segment.recursive = recursive
+			segments = append(segments, segment)
+			offset = next
+		// 91 = [
+		case 91:
+			segment, next, err := parseBracket(expr, offset)
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, segment)
+			offset = next
+		default:
+			return nil, badPathError(expr, offset)
+		}
+	}
+	return segments, nil
+}
+
+// parseDotSegment reads a dot notation key or a '*' wildcard that starts at offset.
+// returns the segment and offset of the next byte after it.
+func parseDotSegment(expr string, offset int) (pathSegment, int, error) {
+	// 42 = *
+	if expr[offset] == 42 && (offset+1 == len(expr) || expr[offset+1] == 46 || expr[offset+1] == 91) {
+		return pathSegment{kind: segmentWildcard, at: offset}, offset + 1, nil
+	}
+	key, next, err := parseDotKey(expr, offset)
+	if err != nil {
+		return pathSegment{}, next, err
+	}
+	return pathSegment{kind: segmentKey, value: key, at: offset}, next, nil
+}
+
+// parseDotKey reads a dot notation key that starts at offset.
+// returns the key and offset of the next byte after the key.
+func parseDotKey(expr string, offset int) (string, int, error) {
+	var sb strings.Builder
+	start := offset
+	for offset < len(expr) {
+		curr := expr[offset]
+		// 46 = ., 91 = [
+		if curr == 46 || curr == 91 {
+			break
+		}
+		// 92 = \
+		if curr == 92 {
+			offset++
+			if offset >= len(expr) {
+				return "", offset, badPathError(expr, offset)
+			}
+			curr = expr[offset]
+			if curr != 46 && curr != 91 && curr != 92 {
+				return "", offset, badPathError(expr, offset)
+			}
+		}
+		sb.WriteByte(curr)
+		offset++
+	}
+	if offset == start {
+		return "", offset, badPathError(expr, offset)
+	}
+	return sb.String(), offset, nil
+}
+
+// parseBracket reads a bracket notation segment. offset must point at '['.
+// It can be a quoted key, an index, a '*' wildcard, a [start:end:step] slice or a [?(...)] filter.
+// returns the segment and offset of the next byte after ']'.
+func parseBracket(expr string, offset int) (pathSegment, int, error) {
+	at := offset
+	offset++
+	offset = skipPathSpace(expr, offset)
+	if offset >= len(expr) {
+		return pathSegment{}, offset, badPathError(expr, offset)
+	}
+	var segment pathSegment
+	curr := expr[offset]
+	switch {
+	// 34 = ", 39 = '
+	case curr == 34 || curr == 39:
+		key, next, err := parseQuotedKey(expr, offset)
+		if err != nil {
+			return pathSegment{}, next, err
+		}
+		segment = pathSegment{kind: segmentKey, value: key}
+		offset = next
+	// 42 = *
+	case curr == 42:
+		segment = pathSegment{kind: segmentWildcard}
+		offset++
+	// 63 = ?
+	case curr == 63:
+		filter, next, err := parseFilter(expr, offset)
+		if err != nil {
+			return pathSegment{}, next, err
+		}
+		segment = pathSegment{kind: segmentFilter, filter: filter}
+		offset = next
+	default:
+		segment = pathSegment{kind: segmentIndex, step: 1}
+		bounds := 0
+		for {
+			offset = skipPathSpace(expr, offset)
+			start := offset
+			// 45 = -
+			if offset < len(expr) && expr[offset] == 45 {
+				offset++
+			}
+			for offset < len(expr) && expr[offset] >= 48 && expr[offset] <= 57 {
+				offset++
+			}
+			number := expr[start:offset]
+			if len(number) != 0 {
+				val, err := strconv.Atoi(number)
+				// leading zeros are not allowed, like JSON numbers, so "01" or "-0" are not indexes.
+				if err != nil || strconv.Itoa(val) != number || number == "-0" {
+					return pathSegment{}, start, badPathError(expr, start)
+				}
+				switch bounds {
+				case 0:
+					segment.value = number
+					segment.start = val
+					segment.hasStart = true
+				case 1:
+					segment.end = val
+					segment.hasEnd = true
+				case 2:
+					if val == 0 {
+						return pathSegment{}, start, badPathError(expr, start)
+					}
+					segment.step = val
+				}
+			}
+			offset = skipPathSpace(expr, offset)
+			// 58 = :
+			if offset < len(expr) && expr[offset] == 58 && bounds < 2 {
+				segment.kind = segmentSlice
+				bounds++
+				offset++
+				continue
+			}
+			if segment.kind == segmentIndex && len(number) == 0 {
+				return pathSegment{}, offset, badPathError(expr, offset)
+			}
+			break
+		}
+	}
+	segment.at = at
+	offset = skipPathSpace(expr, offset)
+	// 93 = ]
+	if offset >= len(expr) || expr[offset] != 93 {
+		return pathSegment{}, offset, badPathError(expr, offset)
+	}
+	return segment, offset + 1, nil
+}
+
+// parseQuotedKey reads a quoted key. offset must point at the opening quote.
+// returns unescaped key and offset of the next byte after the closing quote.
+func parseQuotedKey(expr string, offset int) (string, int, error) {
+	quote := expr[offset]
+	var sb strings.Builder
+	for i := offset + 1; i < len(expr); i++ {
+		curr := expr[i]
+		if curr == quote {
+			return sb.String(), i + 1, nil
+		}
+		// 92 = \
+		if curr != 92 {
+			sb.WriteByte(curr)
+			continue
+		}
+		i++
+		if i >= len(expr) {
+			break
+		}
+		switch expr[i] {
+		case 34, 39, 47, 92:
+			sb.WriteByte(expr[i])
+		case 98:
+			sb.WriteByte(8)
+		case 102:
+			sb.WriteByte(12)
+		case 110:
+			sb.WriteByte(10)
+		case 114:
+			sb.WriteByte(13)
+		case 116:
+			sb.WriteByte(9)
+		case 117:
+			// surrogate pairs are combined like unescape() does for JSON strings.
+			r, size := unescapeUnicode([]byte(expr[i-1:]))
+			if size == 0 {
+				return "", i, badPathError(expr, i)
+			}
+			var buff [4]byte
+			n := utf8.EncodeRune(buff[:], r)
+			sb.Write(buff[:n])
+			i += size - 2
+		default:
+			return "", i, badPathError(expr, i)
+		}
+	}
+	return "", len(expr), badPathError(expr, len(expr))
+}
+
+func skipPathSpace(expr string, offset int) int {
+	for offset < len(expr) && expr[offset] == 32 {
+		offset++
+	}
+	return offset
+}
+
+// isIndex reports whether str can be written as a bracket index, digits without leading zeros.
+func isIndex(str string) bool {
+	if len(str) == 0 || (len(str) > 1 && str[0] == 48) {
+		return false
+	}
+	for i := 0; i < len(str); i++ {
+		if str[i] < 48 || str[i] > 57 {
+			return false
+		}
+	}
+	return true
+}
+
+// isPlainKey reports whether key can be written with dot notation without any escaping.
+func isPlainKey(key string) bool {
+	if len(key) == 0 {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		curr := key[i]
+		if curr == 95 || curr == 45 {
+			continue
+		}
+		if (curr >= 48 && curr <= 57) || (curr >= 65 && curr <= 90) || (curr >= 97 && curr <= 122) {
+			continue
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,147 @@
+package jin
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		expr string
+		path []string
+	}{
+		{``, []string{}},
+		{`$`, []string{}},
+		{`$.repo.name[1]`, []string{"repo", "name", "1"}},
+		{`repo.name[1]`, []string{"repo", "name", "1"}},
+		{`[0][1]`, []string{"0", "1"}},
+		{`$[0]`, []string{"0"}},
+		{`$[10]`, []string{"10"}},
+		{`$[-1]`, []string{"-1"}},
+		{`$[ 2 ]`, []string{"2"}},
+		{`$.a.0`, []string{"a", "0"}},
+		{`$['weird.key'].value`, []string{"weird.key", "value"}},
+		{`$["weird.key"]`, []string{"weird.key"}},
+		{`$['']`, []string{""}},
+		{`$["say \"hi\""]`, []string{`say "hi"`}},
+		{`$['it\'s']`, []string{"it's"}},
+		{`$['a"b']`, []string{`a"b`}},
+		{`$["a'b"]`, []string{"a'b"}},
+		{`$['back\\slash']`, []string{`back\slash`}},
+		{`$['\/\b\f\n\r\t']`, []string{"/\b\f\n\r\t"}},
+		{`$['café']`, []string{"café"}},
+		{`$['\u00e9']`, []string{"é"}},
+		{`$['\ud83d\ude00']`, []string{"😀"}},
+		{`$['a\uD83D\uDE00b']`, []string{"a😀b"}},
+		// lone surrogates are replaced with U+FFFD like unescape() does.
+		{`$['\ud83d']`, []string{"\ufffd"}},
+		{`$['\ude00\ud83d']`, []string{"\ufffd\ufffd"}},
+		{`$['\ud83dx']`, []string{"\ufffdx"}},
+		{`$['😀']`, []string{"😀"}},
+		{`a\.b.c`, []string{"a.b", "c"}},
+		{`a\[0].b`, []string{"a[0]", "b"}},
+		{`a\\b`, []string{`a\b`}},
+		{`$.a['b'][2].c`, []string{"a", "b", "2", "c"}},
+	}
+	for _, test := range tests {
+		path, err := ParsePath(test.expr)
+		if err != nil || !reflect.DeepEqual(path, test.path) {
+			t.Errorf("%v: got %q %v, want %q", test.expr, path, err, test.path)
+		}
+	}
+}
+
+func TestParsePathErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		at   int
+	}{
+		// unterminated quotes
+		{`$['abc`, 6},
+		{`$["abc'`, 7},
+		{`$['abc\'`, 8},
+		{`$['abc']`[:7], 7},
+		// bad escapes
+		{`$['\x']`, 4},
+		{`$['\u12']`, 4},
+		{`$['\u12g4']`, 4},
+		{`$['abc\`, 7},
+		{`a\b`, 2},
+		{`a\`, 2},
+		// empty brackets and bad indexes
+		{`$[]`, 2},
+		{`$[ ]`, 3},
+		{`$[`, 2},
+		{`$[1`, 3},
+		{`$[01]`, 2},
+		{`$[-0]`, 2},
+		{`$[-01]`, 2},
+		{`$[-]`, 2},
+		{`$[a]`, 2},
+		{`$['a'b]`, 5},
+		// trailing and empty dots
+		{`$.`, 2},
+		{`a.`, 2},
+		{`a..`, 3},
+		{`$.a.`, 4},
+		{`a..b`, 3},
+		{`$..a`, 3},
+		{`$.[0]`, 2},
+		// not allowed outside Query()
+		{`$.*`, 2},
+		{`$[*]`, 1},
+		{`$[1:2]`, 1},
+		{`$[?(@.a)]`, 1},
+		// bad characters
+		{`$a`, 1},
+		{`$.a b`, -1},
+	}
+	for _, test := range tests {
+		path, err := ParsePath(test.expr)
+		if test.at == -1 {
+			if err != nil {
+				t.Errorf("%v: unexpected error %v", test.expr, err)
+			}
+			continue
+		}
+		var e *Error
+		if !errors.As(err, &e) || !errors.Is(err, ErrBadPath) {
+			t.Errorf("%v: got %q %v, want ErrBadPath", test.expr, path, err)
+			continue
+		}
+		if e.Offset != test.at {
+			t.Errorf("%v: got offset %v, want %v", test.expr, e.Offset, test.at)
+		}
+	}
+}
+
+func TestMustParsePath(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MustParsePath() does not panic for a bad path")
+		}
+	}()
+	MustParsePath(`$[`)
+}
+
+func TestFormatPathRoundTrip(t *testing.T) {
+	paths := [][]string{
+		{},
+		{"repo", "name", "1"},
+		{"0", "10", "-1", "01", "-0"},
+		{"weird.key", "a[0]", "a]b", "a'b", `a"b`, `a\b`, ""},
+		{"new\nline", "tab\t", "café", "😀", " ", "sp ace"},
+		{"$", "*", "..", "@", "?(@.a)", "1:2"},
+	}
+	for _, path := range paths {
+		expr := FormatPath(path...)
+		back, err := ParsePath(expr)
+		if err != nil || !reflect.DeepEqual(back, path) {
+			t.Errorf("%q: formatted as %v, parsed back as %q %v", path, expr, back, err)
+		}
+	}
+	if expr := FormatPath("a", "0", "b.c"); expr != `$.a[0]['b.c']` {
+		t.Errorf("got %v, want $.a[0]['b.c']", expr)
+	}
+}
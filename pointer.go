@@ -0,0 +1,217 @@
+package jin
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ParsePointer converts an RFC 6901 JSON Pointer to path segments.
+// Returned segments can be given to any function that takes 'path ...string'.
+//
+//	""             -> [] (whole document)
+//	"/repo/name/1" -> ["repo", "name", "1"]
+//	"/a~1b/m~0n"   -> ["a/b", "m~n"]
+//
+// URI fragment representation ("#/repo/name") is also accepted.
+func ParsePointer(pointer string) ([]string, error) {
+	// 35 = #
+	if len(pointer) > 0 && pointer[0] == 35 {
+		unescaped, err := url.PathUnescape(pointer[1:])
+		if err != nil {
+			return nil, badPointerError(pointer, 0)
+		}
+		pointer = unescaped
+	}
+	if len(pointer) == 0 {
+		return []string{}, nil
+	}
+	// 47 = /
+	if pointer[0] != 47 {
+		return nil, badPointerError(pointer, 0)
+	}
+	path := make([]string, 0, 8)
+	start := 1
+	for i := 1; i <= len(pointer); i++ {
+		if i < len(pointer) && pointer[i] != 47 {
+			continue
+		}
+		token := pointer[start:i]
+		// 126 = ~
+		if strings.IndexByte(token, 126) != -1 {
+			var sb strings.Builder
+			for j := 0; j < len(token); j++ {
+				if token[j] != 126 {
+					sb.WriteByte(token[j])
+					continue
+				}
+				if j+1 == len(token) {
+					return nil, badPointerError(pointer, start+j)
+				}
+				j++
+				switch token[j] {
+				// 48 = 0
+				case 48:
+					sb.WriteByte(126)
+				// 49 = 1
+				case 49:
+					sb.WriteByte(47)
+				default:
+					return nil, badPointerError(pointer, start+j)
+				}
+			}
+			token = sb.String()
+		}
+		path = append(path, token)
+		start = i + 1
+	}
+	return path, nil
+}
+
+// FormatPointer is the reverse of ParsePointer.
+// It creates an RFC 6901 JSON Pointer from path segments.
+func FormatPointer(path ...string) string {
+	var sb strings.Builder
+	for _, p := range path {
+		sb.WriteByte(47)
+		for i := 0; i < len(p); i++ {
+			switch p[i] {
+			case 126:
+				sb.WriteString("~0")
+			case 47:
+				sb.WriteString("~1")
+			default:
+				sb.WriteByte(p[i])
+			}
+		}
+	}
+	return sb.String()
+}
+
+// GetPointer is a variation of Get() func.
+// Path of value must be provided as a JSON Pointer.
+func GetPointer(json []byte, pointer string) ([]byte, error) {
+	path, err := jsonPointerPath(json, pointer)
+	if err != nil {
+		return nil, err
+	}
+	return Get(json, path...)
+}
+
+// SetPointer is a variation of Set() func.
+// Path of value must be provided as a JSON Pointer.
+func SetPointer(json []byte, newValue []byte, pointer string) ([]byte, error) {
+	path, err := jsonPointerPath(json, pointer)
+	if err != nil {
+		return json, err
+	}
+	return Set(json, newValue, path...)
+}
+
+// DeletePointer is a variation of Delete() func.
+// Path of value must be provided as a JSON Pointer.
+func DeletePointer(json []byte, pointer string) ([]byte, error) {
+	path, err := jsonPointerPath(json, pointer)
+	if err != nil {
+		return json, err
+	}
+	return Delete(json, path...)
+}
+
+// GetPointer is a variation of Get() func.
+// Path of value must be provided as a JSON Pointer.
+func (p *Parser) GetPointer(pointer string) ([]byte, error) {
+	path, err := p.pointerPath(pointer)
+	if err != nil {
+		return nil, err
+	}
+	return p.Get(path...)
+}
+
+// SetPointer is a variation of Set() func.
+// Path of value must be provided as a JSON Pointer.
+func (p *Parser) SetPointer(newValue []byte, pointer string) error {
+	path, err := p.pointerPath(pointer)
+	if err != nil {
+		return err
+	}
+	return p.Set(newValue, path...)
+}
+
+// DeletePointer is a variation of Delete() func.
+// Path of value must be provided as a JSON Pointer.
+func (p *Parser) DeletePointer(pointer string) error {
+	path, err := p.pointerPath(pointer)
+	if err != nil {
+		return err
+	}
+	return p.Delete(path...)
+}
+
+// jsonPointerPath parses pointer and checks its array indexes in json.
+// Every token is searched from the value of the previous one, so json is read once.
+func jsonPointerPath(json []byte, pointer string) ([]string, error) {
+	start := skipSpace(json, 0)
+	return pointerPath(pointer, func() bool {
+		// 91 = [
+		return start < len(json) && json[start] == 91
+	}, func(token string) bool {
+		_, next, _, err := core(json[start:], true, token)
+		if err != nil {
+			return false
+		}
+		start += next
+		return true
+	})
+}
+
+// pointerPath parses pointer and checks its array indexes in the Parser.
+func (p *Parser) pointerPath(pointer string) ([]string, error) {
+	n := p.core
+	return pointerPath(pointer, func() bool {
+		// 91 = [
+		return len(n.value) > 0 && n.value[0] == 91
+	}, func(token string) bool {
+		down, err := n.walk([]string{token})
+		if err != nil {
+			return false
+		}
+		n = down
+		return true
+	})
+}
+
+// pointerPath parses pointer and rejects array indexes that RFC 6901 does not allow.
+// Paths accept indexes like "-1", "01" or "+1", but an index of a JSON Pointer is "0" or digits without a leading zero.
+// Such tokens are still valid keys of objects, so isArray tells whether the current value is an array
+// and down moves the current value to its child at token, starting from the root.
+// Walk stops at the last such token or at the first token that is not found.
+func pointerPath(pointer string, isArray func() bool, down func(token string) bool) ([]string, error) {
+	path, err := ParsePointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	last := -1
+	for i, token := range path {
+		if !pointerIndex(token) {
+			last = i
+		}
+	}
+	offset := 0
+	for i, token := range path[:last+1] {
+		offset = strings.Index(pointer[offset:], "/") + offset + 1
+		if !pointerIndex(token) && isArray() {
+			return nil, badPointerError(pointer, offset)
+		}
+		if i == last || !down(token) {
+			break
+		}
+	}
+	return path, nil
+}
+
+// pointerIndex returns false for tokens that paths take as an index but a JSON Pointer does not.
+func pointerIndex(token string) bool {
+	index, err := strconv.Atoi(token)
+	return err != nil || (index >= 0 && strconv.Itoa(index) == token)
+}
@@ -0,0 +1,155 @@
+package jin
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPointerArrayIndexes(t *testing.T) {
+	json := []byte(`{"a":[10,20,30],"01":"key","o":{"-1":"neg","+1":"plus"}}`)
+	tests := []struct {
+		pointer string
+		want    string
+		err     error
+	}{
+		{"/a/0", "10", nil},
+		{"/a/2", "30", nil},
+		{"/a/-1", "", ErrBadPointer},
+		{"/a/01", "", ErrBadPointer},
+		{"/a/00", "", ErrBadPointer},
+		{"/a/-0", "", ErrBadPointer},
+		{"/a/+1", "", ErrBadPointer},
+		// same tokens are keys of objects.
+		{"/01", "key", nil},
+		{"/o/-1", "neg", nil},
+		{"/o/+1", "plus", nil},
+	}
+	pars, err := Parse(json)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range tests {
+		got, err := GetPointer(json, test.pointer)
+		parsGot, parsErr := pars.GetPointer(test.pointer)
+		if test.err != nil {
+			if !errors.Is(err, test.err) || !errors.Is(parsErr, test.err) {
+				t.Errorf("%v: got %v and %v, want %v", test.pointer, err, parsErr, test.err)
+			}
+			continue
+		}
+		if err != nil || parsErr != nil || string(got) != test.want || string(parsGot) != test.want {
+			t.Errorf("%v: got %s %v and %s %v, want %v", test.pointer, got, err, parsGot, parsErr, test.want)
+		}
+	}
+	if _, err := SetPointer(json, []byte(`1`), "/a/-1"); !errors.Is(err, ErrBadPointer) {
+		t.Errorf("SetPointer got %v, want ErrBadPointer", err)
+	}
+	if _, err := DeletePointer(json, "/a/01"); !errors.Is(err, ErrBadPointer) {
+		t.Errorf("DeletePointer got %v, want ErrBadPointer", err)
+	}
+	if err := pars.SetPointer([]byte(`1`), "/a/-1"); !errors.Is(err, ErrBadPointer) {
+		t.Errorf("Parser.SetPointer got %v, want ErrBadPointer", err)
+	}
+	if err := pars.DeletePointer("/a/01"); !errors.Is(err, ErrBadPointer) {
+		t.Errorf("Parser.DeletePointer got %v, want ErrBadPointer", err)
+	}
+	var e *Error
+	if _, err := GetPointer(json, "/a/-1"); !errors.As(err, &e) || e.Offset != 3 {
+		t.Errorf("got %v, want offset 3", err)
+	}
+}
+
+func TestParsePointer(t *testing.T) {
+	tests := []struct {
+		pointer string
+		want    []string
+	}{
+		{"", []string{}},
+		{"/", []string{""}},
+		{"//", []string{"", ""}},
+		{"/a", []string{"a"}},
+		{"/a/", []string{"a", ""}},
+		{"/a~1b/m~0n", []string{"a/b", "m~n"}},
+		{"/~01", []string{"~1"}},
+		{"/~10", []string{"/0"}},
+		{"/~0~1~1~0", []string{"~//~"}},
+		{"/ /\"/\\", []string{" ", `"`, `\`}},
+		{"#", []string{}},
+		{"#/a%20b/c%25d", []string{"a b", "c%d"}},
+		{"#/a~1b", []string{"a/b"}},
+	}
+	for _, test := range tests {
+		got, err := ParsePointer(test.pointer)
+		if err != nil || !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%q: got %q %v, want %q", test.pointer, got, err, test.want)
+		}
+		if test.pointer != "" && test.pointer[0] != '#' {
+			if formatted := FormatPointer(got...); formatted != test.pointer {
+				t.Errorf("%q: FormatPointer returned %q", test.pointer, formatted)
+			}
+		}
+	}
+}
+
+func TestParsePointerErrors(t *testing.T) {
+	tests := []struct {
+		pointer string
+		offset  int
+	}{
+		{"a", 0},
+		{"a/b", 0},
+		{"#a", 0},
+		{"/a~", 2},
+		{"/a~2", 3},
+		{"/~/", 1},
+		{"/a/b~x", 5},
+		{"#/a%2", 0},
+	}
+	for _, test := range tests {
+		_, err := ParsePointer(test.pointer)
+		var e *Error
+		if !errors.As(err, &e) || !errors.Is(err, ErrBadPointer) {
+			t.Errorf("%q: got %v, want ErrBadPointer", test.pointer, err)
+			continue
+		}
+		if e.Offset != test.offset {
+			t.Errorf("%q: got offset %v, want %v", test.pointer, e.Offset, test.offset)
+		}
+	}
+}
+
+func TestPointerNestedArrayIndexes(t *testing.T) {
+	json := []byte(` {"b":[{"c":[1,2]},{"c":{"-1":3}}],"": {"01":[4,5]}} `)
+	tests := []struct {
+		pointer string
+		want    string
+		err     error
+	}{
+		{"/b/0/c/1", "2", nil},
+		{"/b/0/c/-1", "", ErrBadPointer},
+		{"/b/01/c", "", ErrBadPointer},
+		{"/b/1/c/-1", "3", nil},
+		{"//01/0", "4", nil},
+		{"//01/00", "", ErrBadPointer},
+		{"/x/01", "", ErrKeyNotFound},
+		{"", string(json), nil},
+	}
+	pars, err := Parse(json)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range tests {
+		got, err := GetPointer(json, test.pointer)
+		parsGot, parsErr := pars.GetPointer(test.pointer)
+		if test.err != nil {
+			if !errors.Is(err, test.err) || !errors.Is(parsErr, test.err) {
+				t.Errorf("%q: got %v and %v, want %v", test.pointer, err, parsErr, test.err)
+			}
+			continue
+		}
+		if err != nil || parsErr != nil || string(got) != test.want || string(parsGot) != test.want {
+			t.Errorf("%q: got %s %v and %s %v, want %v", test.pointer, got, err, parsGot, parsErr, test.want)
+		}
+	}
+}
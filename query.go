@@ -0,0 +1,345 @@
+package jin
+
+import "strconv"
+
+// Match is a single result of a Query.
+// Path is the concrete path of the value, it can be used with any other function that takes a path.
+// Value is the value itself, like Get() quotation marks of string values are stripped.
+type Match struct {
+	Path  []string
+	Value []byte
+}
+
+// Query returns every value that a JSONPath like expression matches, in document order.
+// Besides the syntax of ParsePath() it supports;
+//
+//	$.items[*].id      '*' wildcard, all members of an object or all elements of an array
+//	$..id              '..' recursive descent, matches in any depth
+//	$.items[2:5]       array slices with optional negative bounds and step. [start:end:step]
+//	$.items[?(@.price > 10 && @.tags contains "sale")]
+//	                   filters, selects members of an object or elements of an array
+//
+// Filter expressions can use '@' for current element, relative paths like '@.a.b' or "@['a'][0]",
+// string, number, true, false and null literals, comparison operators '==', '!=', '<', '<=', '>', '>=',
+// 'contains' operator for strings (substring), arrays (element) and objects (key),
+// logical operators '&&', '||', '!' and parentheses. A single relative path checks the existence.
+// Numbers are compared like GetFloat() and strings are compared like GetString() reads them,
+// values with different types are never equal and never ordered.
+//
+// Query does not return an error for paths that does not exist,
+// it simply returns no match for them.
+func Query(json []byte, expr string) ([]Match, error) {
+	segments, err := parsePath(expr)
+	if err != nil {
+		return nil, err
+	}
+	start := 0
+	for start < len(json) && space(json[start]) {
+		start++
+	}
+	if start == len(json) {
+		return nil, badJSONError(json, start, "value")
+	}
+	end, err := valueEnd(json, start)
+	if err != nil {
+		return nil, err
+	}
+	current := []queryNode{{path: []string{}, start: start, end: end}}
+	for _, s := range segments {
+		next := make([]queryNode, 0, len(current))
+		for _, n := range current {
+			if s.recursive {
+				next, err = descend(json, n, s, next)
+			} else {
+				next, err = step(json, n, s, next)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		current = next
+	}
+	matches := make([]Match, len(current))
+	for i, n := range current {
+		matches[i] = Match{Path: n.path, Value: stripQuotesByte(json[n.start:n.end])}
+	}
+	return matches, nil
+}
+
+// queryNode is an intermediate result of a query. start and end are the raw bounds of value.
+type queryNode struct {
+	path  []string
+	start int
+	end   int
+}
+
+// child returns the node of a child, label is the raw key or index that eachChild() gives.
+// Keys are decoded in the path, so paths of matches work with other functions and FormatPath().
+func (n queryNode) child(label string, start, end int) queryNode {
+	path := make([]string, len(n.path)+1)
+	copy(path, n.path)
+	path[len(n.path)] = string(unescape([]byte(label)))
+	return queryNode{path: path, start: start, end: end}
+}
+
+// step applies a single segment to a node and appends results to the list.
+func step(json []byte, n queryNode, s pathSegment, list []queryNode) ([]queryNode, error) {
+	brace := json[n.start]
+	// 91 = [, 123 = {
+	if brace != 91 && brace != 123 {
+		return list, nil
+	}
+	switch s.kind {
+	case segmentKey:
+		if brace != 123 {
+			return list, nil
+		}
+		err := eachChild(json, n.start, func(label string, start, end int) bool {
+			if keyMatch(label, s.value) {
+				list = append(list, n.child(label, start, end))
+				return false
+			}
+			return true
+		})
+		return list, err
+	case segmentWildcard:
+		err := eachChild(json, n.start, func(label string, start, end int) bool {
+			list = append(list, n.child(label, start, end))
+			return true
+		})
+		return list, err
+	case segmentFilter:
+		var matchErr error
+		err := eachChild(json, n.start, func(label string, start, end int) bool {
+			found, err := s.filter.match(json[start:end])
+			if err != nil {
+				matchErr = errorIn(err, json, start)
+				return false
+			}
+			if found {
+				list = append(list, n.child(label, start, end))
+			}
+			return true
+		})
+		if err == nil {
+			err = matchErr
+		}
+		return list, err
+	case segmentIndex, segmentSlice:
+		if brace != 91 {
+			return list, nil
+		}
+		children := make([]queryNode, 0, 16)
+		err := eachChild(json, n.start, func(label string, start, end int) bool {
+			children = append(children, n.child(label, start, end))
+			return true
+		})
+		if err != nil {
+			return list, err
+		}
+		if s.kind == segmentIndex {
+			index := s.start
+			if index < 0 {
+				index += len(children)
+			}
+			if index >= 0 && index < len(children) {
+				list = append(list, children[index])
+			}
+			return list, nil
+		}
+		for _, i := range sliceIndexes(s, len(children)) {
+			list = append(list, children[i])
+		}
+		return list, nil
+	}
+	return list, nil
+}
+
+// descend applies a segment to a node and all of its descendants.
+func descend(json []byte, n queryNode, s pathSegment, list []queryNode) ([]queryNode, error) {
+	list, err := step(json, n, s, list)
+	if err != nil {
+		return list, err
+	}
+	brace := json[n.start]
+	if brace != 91 && brace != 123 {
+		return list, nil
+	}
+	err = eachChild(json, n.start, func(label string, start, end int) bool {
+		list, err = descend(json, n.child(label, start, end), s, list)
+		return err == nil
+	})
+	return list, err
+}
+
+// sliceIndexes returns the indexes that a slice segment selects from an array with given length.
+func sliceIndexes(s pathSegment, length int) []int {
+	normalize := func(i int) int {
+		if i < 0 {
+			i += length
+			if i < 0 {
+				if s.step < 0 {
+					return -1
+				}
+				return 0
+			}
+		}
+		if i >= length {
+			if s.step < 0 {
+				return length - 1
+			}
+			return length
+		}
+		return i
+	}
+	var start, end int
+	if s.step > 0 {
+		start, end = 0, length
+	} else {
+		start, end = length-1, -1
+	}
+	if s.hasStart {
+		start = normalize(s.start)
+	}
+	if s.hasEnd {
+		end = normalize(s.end)
+	}
+	indexes := make([]int, 0, 16)
+	if s.step > 0 {
+		for i := start; i < end; i += s.step {
+			indexes = append(indexes, i)
+		}
+		return indexes
+	}
+	for i := start; i > end; i += s.step {
+		indexes = append(indexes, i)
+	}
+	return indexes
+}
+
+// eachChild calls callback for every direct child of the object or array
+// that starts at json[start]. label is the raw key of the child for objects, escape sequences are not decoded,
+// and index for arrays.
+// start and end are the raw bounds of child value, quotation marks not stripped.
+// Iteration stops when callback returns false.
+func eachChild(json []byte, start int, callback func(label string, start, end int) bool) error {
+	brace := json[start]
+	isObject := brace == 123
+	index := 0
+	i := start + 1
+	for {
+		i = skipSpace(json, i)
+		if i >= len(json) {
+			return badJSONError(json, i, "value")
+		}
+		// 93 = ], 125 = }
+		if json[i] == brace+2 && index == 0 {
+			return nil
+		}
+		var label string
+		if isObject {
+			// 34 = "
+			if json[i] != 34 {
+				return badJSONError(json, i, "'\"'")
+			}
+			keyEnd, err := valueEnd(json, i)
+			if err != nil {
+				return err
+			}
+			label = string(json[i+1 : keyEnd-1])
+			i = skipSpace(json, keyEnd)
+			// 58 = :
+			if i >= len(json) || json[i] != 58 {
+				return badJSONError(json, i, "':'")
+			}
+			i = skipSpace(json, i+1)
+		} else {
+			label = strconv.Itoa(index)
+		}
+		end, err := valueEnd(json, i)
+		if err != nil {
+			return err
+		}
+		if !callback(label, i, end) {
+			return nil
+		}
+		index++
+		i = skipSpace(json, end)
+		if i >= len(json) {
+			return badJSONError(json, i, "',' or '"+string(brace+2)+"'")
+		}
+		// 44 = ,
+		if json[i] == 44 {
+			i++
+			continue
+		}
+		if json[i] == brace+2 {
+			return nil
+		}
+		return badJSONError(json, i, "',' or '"+string(brace+2)+"'")
+	}
+}
+
+// valueEnd returns the end of the value that starts at json[start].
+// For strings, objects and arrays end is the index after closing character.
+func valueEnd(json []byte, start int) (int, error) {
+	if start >= len(json) {
+		return -1, badJSONError(json, start, "value")
+	}
+	switch json[start] {
+	// 34 = "
+	case 34:
+		for i := start + 1; i < len(json); i++ {
+			curr := json[i]
+			// 92 = \
+			if curr == 92 {
+				i++
+				continue
+			}
+			if curr == 34 {
+				return i + 1, nil
+			}
+		}
+		return -1, badJSONError(json, len(json), "'\"'")
+	// 91 = [, 123 = {
+	case 91, 123:
+		level := 0
+		for i := start; i < len(json); i++ {
+			curr := json[i]
+			switch curr {
+			case 34:
+				end, err := valueEnd(json, i)
+				if err != nil {
+					return -1, err
+				}
+				i = end - 1
+			case 91, 123:
+				level++
+			case 93, 125:
+				level--
+				if level == 0 {
+					return i + 1, nil
+				}
+			}
+		}
+		return -1, badJSONError(json, len(json), "'"+string(json[start]+2)+"'")
+	// 44 = , 58 = : 93 = ] 125 = }
+	case 44, 58, 93, 125:
+		return -1, badJSONError(json, start, "value")
+	default:
+		for i := start; i < len(json); i++ {
+			curr := json[i]
+			if space(curr) || curr == 44 || curr == 93 || curr == 125 {
+				return i, nil
+			}
+		}
+		return len(json), nil
+	}
+}
+
+func skipSpace(json []byte, offset int) int {
+	for offset < len(json) && space(json[offset]) {
+		offset++
+	}
+	return offset
+}
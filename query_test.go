@@ -0,0 +1,38 @@
+package jin
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQueryEscapedKeys(t *testing.T) {
+	json := []byte(`{"k\"q":1,"a":{"caf\u00e9":{"k\"q":2}}}`)
+	tests := []struct {
+		expr  string
+		paths [][]string
+	}{
+		{`$['k"q']`, [][]string{{`k"q`}}},
+		{`$..['k"q']`, [][]string{{`k"q`}, {"a", "café", `k"q`}}},
+		{`$.a['café']`, [][]string{{"a", "café"}}},
+		{`$.a.*`, [][]string{{"a", "café"}}},
+	}
+	for _, test := range tests {
+		matches, err := Query(json, test.expr)
+		if err != nil {
+			t.Errorf("%v: unexpected error %v", test.expr, err)
+			continue
+		}
+		paths := make([][]string, len(matches))
+		for i, m := range matches {
+			paths[i] = m.Path
+			// paths of matches round-trip through FormatPath() and Query().
+			again, err := Query(json, FormatPath(m.Path...))
+			if err != nil || len(again) != 1 || string(again[0].Value) != string(m.Value) {
+				t.Errorf("%v: path %q does not round-trip, got %v %v", test.expr, FormatPath(m.Path...), again, err)
+			}
+		}
+		if !reflect.DeepEqual(paths, test.paths) {
+			t.Errorf("%v: got %q, want %q", test.expr, paths, test.paths)
+		}
+	}
+}
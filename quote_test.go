@@ -0,0 +1,89 @@
+package jin
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestQuote(t *testing.T) {
+	tests := []struct {
+		str   string
+		quote string
+		html  string
+	}{
+		{"", `""`, `""`},
+		{"abc", `"abc"`, `"abc"`},
+		{`a"b\c`, `"a\"b\\c"`, `"a\"b\\c"`},
+		{"\n\r\t\b\f", `"\n\r\t\b\f"`, `"\n\r\t\b\f"`},
+		{"\x00\x01\x1f", `"\u0000\u0001\u001f"`, `"\u0000\u0001\u001f"`},
+		{"\x7f", "\"\x7f\"", "\"\x7f\""},
+		{"/", `"/"`, `"/"`},
+		{"\u2028\u2029", `"\u2028\u2029"`, `"\u2028\u2029"`},
+		{"a\xffb", `"a\ufffdb"`, `"a\ufffdb"`},
+		{"\xc3", `"\ufffd"`, `"\ufffd"`},
+		{"\xed\xa0\x80", `"\ufffd\ufffd\ufffd"`, `"\ufffd\ufffd\ufffd"`},
+		{"café 😀", `"café 😀"`, `"café 😀"`},
+		{"<a&b>", `"<a&b>"`, `"\u003ca\u0026b\u003e"`},
+		{`"quoted"`, `"\"quoted\""`, `"\"quoted\""`},
+		{`"\u0041"`, `"\"\\u0041\""`, `"\"\\u0041\""`},
+	}
+	for _, test := range tests {
+		if got := Quote(test.str); got != test.quote {
+			t.Errorf("Quote(%q): got %v, want %v", test.str, got, test.quote)
+		}
+		if got := QuoteHTML(test.str); got != test.html {
+			t.Errorf("QuoteHTML(%q): got %v, want %v", test.str, got, test.html)
+		}
+		if !Valid([]byte(Quote(test.str))) || !Valid([]byte(QuoteHTML(test.str))) {
+			t.Errorf("%q: quoted string is not valid JSON", test.str)
+		}
+	}
+}
+
+func TestStringWriters(t *testing.T) {
+	values := []string{"42", "-1.5", "true", "null", `"quoted"`, "[1,2]", `{"a":1}`, "a\"b\n", "\u2028<&>"}
+	for _, value := range values {
+		expected := Quote(value)
+		check := func(name string, err error, get func() ([]byte, string, error)) {
+			if err != nil {
+				t.Errorf("%v(%q): %v", name, value, err)
+				return
+			}
+			raw, got, err := get()
+			if err != nil || `"`+string(raw)+`"` != expected || got != value {
+				t.Errorf("%v(%q): got %s, want %v", name, value, raw, expected)
+			}
+		}
+		interpreter := func(json []byte, path ...string) func() ([]byte, string, error) {
+			return func() ([]byte, string, error) {
+				raw, err := Get(json, path...)
+				got, _ := GetString(json, path...)
+				return raw, got, err
+			}
+		}
+		json, err := SetString([]byte(`{"a":0}`), value, "a")
+		check("SetString", err, interpreter(json, "a"))
+		json, err = AddString([]byte(`[0]`), value)
+		check("AddString", err, interpreter(json, "1"))
+		json, err = InsertString([]byte(`[0]`), 0, value)
+		check("InsertString", err, interpreter(json, "0"))
+		json, err = AddKeyValueString([]byte(`{}`), "a", value)
+		check("AddKeyValueString", err, interpreter(json, "a"))
+
+		pars, _ := Parse([]byte(`{"a":0,"b":[0,0]}`))
+		parser := func(path ...string) func() ([]byte, string, error) {
+			return func() ([]byte, string, error) {
+				raw, err := pars.Get(path...)
+				got, _ := pars.GetString(path...)
+				return raw, got, err
+			}
+		}
+		check("Parser.SetString", pars.SetString(value, "a"), parser("a"))
+		check("Parser.AddString", pars.AddString(value, "b"), parser("b", "2"))
+		check("Parser.InsertString", pars.InsertString(0, value, "b"), parser("b", "0"))
+		check("Parser.AddKeyValueString", pars.AddKeyValueString("c", value), parser("c"))
+	}
+	if _, err := SetString([]byte(`{"a":0}`), "", "a"); !errors.Is(err, ErrNullNewValue) {
+		t.Errorf("got %v, want ErrNullNewValue", err)
+	}
+}
@@ -0,0 +1,513 @@
+package jin
+
+import (
+	"io"
+	"strconv"
+)
+
+// Buffer sizes of Decoder.
+const (
+	// DefaultMaxBuffer is the buffer limit of NewDecoder(), it is the largest value that Next() can return.
+	DefaultMaxBuffer = 16 << 20
+	initialBuffer    = 4096
+)
+
+// Decoder reads a JSON document from an io.Reader with a bounded buffer,
+// so documents larger than memory can be walked element by element.
+// Find() moves to a value like core() finds it, Next() returns the members of an object
+// or the elements of an array at that value one at a time.
+//
+//	dec := jin.NewDecoder(file)
+//	err := dec.Find("export", "users")
+//	for {
+//		_, user, err := dec.Next()
+//		if err == io.EOF {
+//			break
+//		}
+//		...
+//	}
+//
+// Decoder does not validate values, like other functions of this package,
+// it only reads enough to find where a value ends.
+type Decoder struct {
+	r   io.Reader
+	buf []byte
+	// pos is the read position in buf.
+	pos int
+	// offset is the offset of buf[0] in the input.
+	offset int
+	// max is the buffer limit.
+	max int
+	// err is the error of the last read, io.EOF at the end of input.
+	err error
+	// container is the brace of the object or array that Next() iterates, zero before the first Next().
+	container byte
+	index     int
+	done      bool
+}
+
+// NewDecoder returns a Decoder that reads r, with DefaultMaxBuffer limit.
+func NewDecoder(r io.Reader) *Decoder {
+	return NewDecoderSize(r, DefaultMaxBuffer)
+}
+
+// NewDecoderSize returns a Decoder that reads r,
+// maxBuffer is the largest value that Next() can return and the largest key, larger ones return an error with code ErrValueTooLarge.
+// Values that Find() skips are not kept in the buffer, so they can be larger than maxBuffer.
+func NewDecoderSize(r io.Reader, maxBuffer int) *Decoder {
+	size := initialBuffer
+	if size > maxBuffer {
+		size = maxBuffer
+	}
+	return &Decoder{r: r, buf: make([]byte, 0, size), max: maxBuffer}
+}
+
+// Find moves the decoder to the value that path has pointed, path is relative to the current value.
+// Keys are matched like Get() matches them, negative indexes are not supported.
+// It must be called before Next().
+func (d *Decoder) Find(path ...string) error {
+	for _, currentPath := range path {
+		i, err := d.skipSpace(0)
+		if err != nil {
+			return err
+		}
+		brace, err := d.peek(i)
+		if err != nil {
+			return d.eofError(i, "'{' or '['", err)
+		}
+		d.pos += i
+		// 91 = [, 123 = {
+		switch brace {
+		case 123:
+			err = d.findKey(currentPath)
+		case 91:
+			index, convErr := strconv.Atoi(currentPath)
+			if convErr != nil {
+				return indexExpectedError().at(d.offset+d.pos, currentPath)
+			}
+			if index < 0 {
+				return indexOutOfRangeError().at(d.offset+d.pos, currentPath)
+			}
+			err = d.findIndex(index, currentPath)
+		default:
+			return keyNotFoundError().at(d.offset+d.pos, currentPath)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Next returns the next member of the object or the next element of the array at the current value.
+// label is the decoded key for objects and the index for arrays.
+// It returns io.EOF after the last member, value is valid until the next call of Next().
+func (d *Decoder) Next() (string, []byte, error) {
+	if d.done {
+		return "", nil, io.EOF
+	}
+	if d.container == 0 {
+		i, err := d.skipSpace(0)
+		if err != nil {
+			return "", nil, err
+		}
+		brace, err := d.peek(i)
+		if err != nil {
+			return "", nil, d.eofError(i, "'{' or '['", err)
+		}
+		if brace != 91 && brace != 123 {
+			return "", nil, d.streamError(i, "'{' or '['")
+		}
+		d.container = brace
+		d.pos += i + 1
+	}
+	// 93 = ], 125 = }
+	closing := d.container + 2
+	i, err := d.skipSpace(0)
+	if err != nil {
+		return "", nil, err
+	}
+	curr, err := d.peek(i)
+	if err != nil {
+		return "", nil, d.eofError(i, "'"+string(closing)+"'", err)
+	}
+	if curr == closing {
+		d.pos += i + 1
+		d.done = true
+		return "", nil, io.EOF
+	}
+	if d.index > 0 {
+		// 44 = ,
+		if curr != 44 {
+			return "", nil, d.streamError(i, "',' or '"+string(closing)+"'")
+		}
+		i, err = d.skipSpace(i + 1)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+	var label string
+	// 123 = {
+	if d.container == 123 {
+		var keyStart, keyEnd int
+		keyStart, keyEnd, i, err = d.member(i)
+		if err != nil {
+			return "", nil, err
+		}
+		label = string(unescape(d.buf[d.pos+keyStart+1 : d.pos+keyEnd-1]))
+	} else {
+		label = strconv.Itoa(d.index)
+	}
+	end, err := d.skipValue(i)
+	if err != nil {
+		return "", nil, err
+	}
+	value := d.buf[d.pos+i : d.pos+end]
+	d.pos += end
+	d.index++
+	return label, value, nil
+}
+
+// findKey moves to the value of key in the object at d.pos.
+func (d *Decoder) findKey(key string) error {
+	i := 1
+	for n := 0; ; n++ {
+		var err error
+		i, err = d.skipSpace(i)
+		if err != nil {
+			return err
+		}
+		curr, err := d.peek(i)
+		if err != nil {
+			return d.eofError(i, "'}'", err)
+		}
+		// 125 = }
+		if curr == 125 {
+			return keyNotFoundError().at(d.offset+d.pos+i, key)
+		}
+		if n > 0 {
+			// 44 = ,
+			if curr != 44 {
+				return d.streamError(i, "',' or '}'")
+			}
+			i, err = d.skipSpace(i + 1)
+			if err != nil {
+				return err
+			}
+		}
+		keyStart, keyEnd, valueStart, err := d.member(i)
+		if err != nil {
+			return err
+		}
+		if keyMatch(byteArrayToString(d.buf[d.pos+keyStart+1:d.pos+keyEnd-1]), key) {
+			d.pos += valueStart
+			return nil
+		}
+		// skipped members are not kept in the buffer.
+		if err := d.discardValue(valueStart); err != nil {
+			return err
+		}
+		i = 0
+	}
+}
+
+// findIndex moves to the element at index in the array at d.pos.
+func (d *Decoder) findIndex(index int, label string) error {
+	i := 1
+	for n := 0; ; n++ {
+		var err error
+		i, err = d.skipSpace(i)
+		if err != nil {
+			return err
+		}
+		curr, err := d.peek(i)
+		if err != nil {
+			return d.eofError(i, "']'", err)
+		}
+		// 93 = ]
+		if curr == 93 {
+			return indexOutOfRangeError().at(d.offset+d.pos+i, label)
+		}
+		if n > 0 {
+			// 44 = ,
+			if curr != 44 {
+				return d.streamError(i, "',' or ']'")
+			}
+			i, err = d.skipSpace(i + 1)
+			if err != nil {
+				return err
+			}
+		}
+		if n == index {
+			d.pos += i
+			return nil
+		}
+		// skipped elements are not kept in the buffer.
+		if err := d.discardValue(i); err != nil {
+			return err
+		}
+		i = 0
+	}
+}
+
+// member reads a key and colon at d.pos+i, returns the key bounds with quotation marks and the value start.
+func (d *Decoder) member(i int) (int, int, int, error) {
+	curr, err := d.peek(i)
+	if err != nil {
+		return -1, -1, -1, d.eofError(i, "'\"'", err)
+	}
+	// 34 = "
+	if curr != 34 {
+		return -1, -1, -1, d.streamError(i, "'\"'")
+	}
+	keyEnd, err := d.skipValue(i)
+	if err != nil {
+		return -1, -1, -1, err
+	}
+	colon, err := d.skipSpace(keyEnd)
+	if err != nil {
+		return -1, -1, -1, err
+	}
+	curr, err = d.peek(colon)
+	if err != nil {
+		return -1, -1, -1, d.eofError(colon, "':'", err)
+	}
+	// 58 = :
+	if curr != 58 {
+		return -1, -1, -1, d.streamError(colon, "':'")
+	}
+	valueStart, err := d.skipSpace(colon + 1)
+	if err != nil {
+		return -1, -1, -1, err
+	}
+	return i, keyEnd, valueStart, nil
+}
+
+// skipValue returns the offset after the value that starts at d.pos+i, offsets are relative to d.pos.
+func (d *Decoder) skipValue(i int) (int, error) {
+	curr, err := d.peek(i)
+	if err != nil {
+		return -1, d.eofError(i, "value", err)
+	}
+	switch curr {
+	// 34 = "
+	case 34:
+		for i++; ; i++ {
+			curr, err = d.peek(i)
+			if err != nil {
+				return -1, d.eofError(i, "'\"'", err)
+			}
+			// 92 = \
+			if curr == 92 {
+				i++
+				continue
+			}
+			if curr == 34 {
+				return i + 1, nil
+			}
+		}
+	// 91 = [, 123 = {
+	case 91, 123:
+		// closers keeps the expected closing brace of every open container, ] is [+2 and } is {+2.
+		var closers []byte
+		for ; ; i++ {
+			curr, err = d.peek(i)
+			if err != nil {
+				return -1, d.eofError(i, "'"+string(closers[len(closers)-1])+"'", err)
+			}
+			switch curr {
+			case 34:
+				end, err := d.skipValue(i)
+				if err != nil {
+					return -1, err
+				}
+				i = end - 1
+			case 91, 123:
+				closers = append(closers, curr+2)
+			case 93, 125:
+				if curr != closers[len(closers)-1] {
+					return -1, d.streamError(i, "'"+string(closers[len(closers)-1])+"'")
+				}
+				closers = closers[:len(closers)-1]
+				if len(closers) == 0 {
+					return i + 1, nil
+				}
+			}
+		}
+	// 44 = , 58 = : 93 = ] 125 = }
+	case 44, 58, 93, 125:
+		return -1, d.streamError(i, "value")
+	}
+	for ; ; i++ {
+		curr, err = d.peek(i)
+		if err == io.EOF {
+			return i, nil
+		}
+		if err != nil {
+			return -1, err
+		}
+		if space(curr) || curr == 44 || curr == 93 || curr == 125 {
+			return i, nil
+		}
+	}
+}
+
+// discardValue skips the value that starts at d.pos+i and moves d.pos to its end.
+// d.pos moves while reading, so the value is not kept in the buffer and can be larger than it.
+func (d *Decoder) discardValue(i int) error {
+	d.pos += i
+	curr, err := d.peek(0)
+	if err != nil {
+		return d.eofError(0, "value", err)
+	}
+	switch curr {
+	// 34 = "
+	case 34:
+		return d.discardString()
+	// 91 = [, 123 = {
+	case 91, 123:
+		// closers keeps the expected closing brace of every open container, ] is [+2 and } is {+2.
+		var closers []byte
+		for {
+			curr, err = d.peek(0)
+			if err != nil {
+				return d.eofError(0, "'"+string(closers[len(closers)-1])+"'", err)
+			}
+			switch curr {
+			case 34:
+				if err := d.discardString(); err != nil {
+					return err
+				}
+				continue
+			case 91, 123:
+				closers = append(closers, curr+2)
+			case 93, 125:
+				if curr != closers[len(closers)-1] {
+					return d.streamError(0, "'"+string(closers[len(closers)-1])+"'")
+				}
+				closers = closers[:len(closers)-1]
+				if len(closers) == 0 {
+					d.pos++
+					return nil
+				}
+			}
+			d.pos++
+		}
+	// 44 = , 58 = : 93 = ] 125 = }
+	case 44, 58, 93, 125:
+		return d.streamError(0, "value")
+	}
+	for {
+		curr, err = d.peek(0)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if space(curr) || curr == 44 || curr == 93 || curr == 125 {
+			return nil
+		}
+		d.pos++
+	}
+}
+
+// discardString skips the string that starts at d.pos and moves d.pos after its closing quotation mark.
+func (d *Decoder) discardString() error {
+	escaped := false
+	for d.pos++; ; d.pos++ {
+		curr, err := d.peek(0)
+		if err != nil {
+			return d.eofError(0, "'\"'", err)
+		}
+		switch {
+		case escaped:
+			escaped = false
+		// 92 = \
+		case curr == 92:
+			escaped = true
+		// 34 = "
+		case curr == 34:
+			d.pos++
+			return nil
+		}
+	}
+}
+
+// skipSpace returns the offset of the first non-space character from d.pos+i, offsets are relative to d.pos.
+func (d *Decoder) skipSpace(i int) (int, error) {
+	for {
+		curr, err := d.peek(i)
+		if err == io.EOF {
+			return i, nil
+		}
+		if err != nil {
+			return -1, err
+		}
+		if !space(curr) {
+			return i, nil
+		}
+		i++
+	}
+}
+
+// peek returns the byte at d.pos+i, it reads more input if it is needed.
+// Reading may move the buffer, so callers keep offsets relative to d.pos.
+func (d *Decoder) peek(i int) (byte, error) {
+	for d.pos+i >= len(d.buf) {
+		if err := d.fill(); err != nil {
+			return 0, err
+		}
+	}
+	return d.buf[d.pos+i], nil
+}
+
+// fill drops the consumed part of the buffer and reads more input,
+// buffer grows up to the limit if it is full.
+func (d *Decoder) fill() error {
+	if d.err != nil {
+		return d.err
+	}
+	if d.pos > 0 {
+		n := copy(d.buf, d.buf[d.pos:])
+		d.offset += d.pos
+		d.buf = d.buf[:n]
+		d.pos = 0
+	}
+	if len(d.buf) == cap(d.buf) {
+		if cap(d.buf) >= d.max {
+			return valueTooLargeError(d.max).at(d.offset, "")
+		}
+		size := cap(d.buf) * 2
+		if size > d.max {
+			size = d.max
+		}
+		buf := make([]byte, len(d.buf), size)
+		copy(buf, d.buf)
+		d.buf = buf
+	}
+	n, err := d.r.Read(d.buf[len(d.buf):cap(d.buf)])
+	d.buf = d.buf[:len(d.buf)+n]
+	if err != nil {
+		d.err = err
+		if n == 0 {
+			return err
+		}
+	}
+	return nil
+}
+
+// eofError converts an unexpected end of input to a bad json error, other errors are returned as they are.
+func (d *Decoder) eofError(i int, expected string, err error) error {
+	if err == io.EOF {
+		return d.streamError(i, expected)
+	}
+	return err
+}
+
+// streamError returns a bad json error at d.pos+i. Line and column are unknown in a stream.
+func (d *Decoder) streamError(i int, expected string) *Error {
+	e := newError(ErrBadJSON).at(d.offset+d.pos+i, "")
+	e.Expected = expected
+	return e
+}
@@ -0,0 +1,119 @@
+package jin
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// streamAll finds path with a decoder of maxBuffer size and returns all values that Next() returns.
+func streamAll(json string, maxBuffer int, path ...string) ([]string, error) {
+	dec := NewDecoderSize(iotest.OneByteReader(strings.NewReader(json)), maxBuffer)
+	if err := dec.Find(path...); err != nil {
+		return nil, err
+	}
+	var values []string
+	for {
+		_, value, err := dec.Next()
+		if err == io.EOF {
+			return values, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, string(value))
+	}
+}
+
+func TestDecoderSkipsLargeValues(t *testing.T) {
+	large := strings.Repeat("x", 100)
+	tests := []struct {
+		json string
+		path []string
+		want string
+	}{
+		{`{"meta":"` + large + `","users":[1,2]}`, []string{"users"}, "1,2"},
+		{`{"meta":"a\"` + large + `\\","users":[1,2]}`, []string{"users"}, "1,2"},
+		{`{"meta":{"a":["` + large + `",{"b":"]}"}]},"users":[1,2]}`, []string{"users"}, "1,2"},
+		{`[` + strings.Repeat(`"`+large+`",`, 10) + `[3,4]]`, []string{"10"}, "3,4"},
+		{`[` + strings.Repeat(large[:50]+`1,`, 3) + `[5]]`, []string{"3"}, "5"},
+	}
+	for _, test := range tests {
+		values, err := streamAll(test.json, 32, test.path...)
+		if err != nil {
+			t.Errorf("%v: unexpected error %v", test.path, err)
+			continue
+		}
+		if got := strings.Join(values, ","); got != test.want {
+			t.Errorf("%v: got %q, want %q", test.path, got, test.want)
+		}
+	}
+}
+
+func TestDecoderLimitsReturnedValues(t *testing.T) {
+	large := strings.Repeat("x", 100)
+	_, err := streamAll(`{"users":["`+large+`"]}`, 32, "users")
+	if !errors.Is(err, ErrValueTooLarge) {
+		t.Errorf("got %v, want ErrValueTooLarge for a value larger than buffer", err)
+	}
+	_, err = streamAll(`{"`+large+`":1,"users":[1]}`, 32, "users")
+	if !errors.Is(err, ErrValueTooLarge) {
+		t.Errorf("got %v, want ErrValueTooLarge for a key larger than buffer", err)
+	}
+}
+
+func TestDecoderErrors(t *testing.T) {
+	tests := []struct {
+		json string
+		path []string
+		want error
+	}{
+		{`{"a":1}`, []string{"b"}, ErrKeyNotFound},
+		{`[1,2]`, []string{"2"}, ErrIndexOutOfRange},
+		{`[1,2]`, []string{"a"}, ErrIndexExpected},
+		{`{"a":"unterminated`, []string{"b"}, ErrBadJSON},
+		{`{"a":[1,2}`, []string{"b"}, ErrBadJSON},
+		{`{"a" 1}`, []string{"a"}, ErrBadJSON},
+		{`{"a":1 "b":2}`, []string{"b"}, ErrBadJSON},
+	}
+	for _, test := range tests {
+		_, err := streamAll(test.json, 32, test.path...)
+		if !errors.Is(err, test.want) {
+			t.Errorf("%s %v: got %v, want %v", test.json, test.path, err, test.want)
+		}
+	}
+}
+
+func TestDecoderMismatchedBrackets(t *testing.T) {
+	tests := []struct {
+		json     string
+		path     []string
+		offset   int
+		expected string
+	}{
+		// skipped members
+		{`{"a":[1}],"b":2}`, []string{"b"}, 7, "']'"},
+		{`{"a":{"c":1]},"b":2}`, []string{"b"}, 11, "'}'"},
+		{`[[{"c":[1]]},2],3]`, []string{"1"}, 10, "'}'"},
+		// returned values
+		{`{"a":[[1},2]}`, []string{"a"}, 8, "']'"},
+		{`{"a":[{"c":"]"]]}`, []string{"a"}, 14, "'}'"},
+	}
+	for _, test := range tests {
+		_, err := streamAll(test.json, 32, test.path...)
+		var e *Error
+		if !errors.As(err, &e) || !errors.Is(err, ErrBadJSON) {
+			t.Errorf("%s: got %v, want ErrBadJSON", test.json, err)
+			continue
+		}
+		if e.Offset != test.offset || e.Expected != test.expected {
+			t.Errorf("%s: got offset %v expected %v, want %v %v", test.json, e.Offset, e.Expected, test.offset, test.expected)
+		}
+	}
+	values, err := streamAll(`{"a":[{"b":"}]"},[{}]],"c":[1]}`, 32, "c")
+	if err != nil || strings.Join(values, ",") != "1" {
+		t.Errorf("got %v %v, want [1]", values, err)
+	}
+}
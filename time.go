@@ -0,0 +1,127 @@
+package jin
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Layouts of Unix timestamps for GetTime() and SetTime(), other layouts are layouts of time.Parse().
+const (
+	// LayoutUnix is Unix time in seconds as a number, like 1700000000 or 1700000000.25.
+	LayoutUnix = "unix"
+	// LayoutUnixMilli is Unix time in milliseconds as a number, like 1700000000000.
+	LayoutUnixMilli = "unixmilli"
+)
+
+// parseTime converts val to time with layout, empty layout is time.RFC3339Nano.
+// Unix timestamps are returned in UTC.
+func parseTime(val string, layout string) (time.Time, error) {
+	switch layout {
+	case LayoutUnix:
+		return unixTime(val, time.Second)
+	case LayoutUnixMilli:
+		return unixTime(val, time.Millisecond)
+	case "":
+		layout = time.RFC3339Nano
+	}
+	t, err := time.Parse(layout, val)
+	if err != nil {
+		return time.Time{}, timeParseError(val, layout)
+	}
+	return t, nil
+}
+
+// unixTime converts a Unix timestamp in unit to time, fractional timestamps are accepted.
+// Integer and fraction parts are parsed separately, a float64 can not hold nanoseconds of today's timestamps.
+// Digits after nanoseconds are truncated.
+func unixTime(val string, unit time.Duration) (time.Time, error) {
+	if len(val) == 0 || !isNumber(val) {
+		return time.Time{}, timeParseError(val, "unix")
+	}
+	num := val
+	// 45 = -
+	neg := num[0] == 45
+	if neg {
+		num = num[1:]
+	}
+	num, expPart, hasExp := strings.Cut(strings.ToLower(num), "e")
+	intPart, frac, _ := strings.Cut(num, ".")
+	digits := strings.TrimLeft(intPart+frac, "0")
+	if len(digits) == 0 {
+		return time.Unix(0, 0).UTC(), nil
+	}
+	exp := 0
+	if hasExp {
+		var err error
+		exp, err = strconv.Atoi(expPart)
+		// exponents that does not fit to int are far out of range of time anyway.
+		if err != nil || exp > 1000 || exp < -1000 {
+			// 45 = -
+			if expPart[0] != 45 {
+				return time.Time{}, numberOverflowError(val, "time.Time")
+			}
+			exp = -1000
+		}
+	}
+	// point is the position of the decimal point of seconds in digits.
+	point := len(intPart) + exp - (len(intPart) + len(frac) - len(digits))
+	if unit == time.Millisecond {
+		point -= 3
+	}
+	// first digit is not zero, so more than 19 digits overflows int64.
+	if point > 19 {
+		return time.Time{}, numberOverflowError(val, "time.Time")
+	}
+	var sec int64
+	if point > 0 {
+		secDigits, rest := digits, ""
+		if point < len(digits) {
+			secDigits, rest = digits[:point], digits[point:]
+		} else {
+			secDigits += strings.Repeat("0", point-len(digits))
+		}
+		var err error
+		sec, err = strconv.ParseInt(secDigits, 10, 64)
+		if err != nil {
+			return time.Time{}, numberOverflowError(val, "time.Time")
+		}
+		digits = rest
+		point = 0
+	}
+	var nsec int64
+	if -point < 9 {
+		nanoDigits := strings.Repeat("0", -point) + digits
+		if len(nanoDigits) > 9 {
+			nanoDigits = nanoDigits[:9]
+		}
+		nanoDigits += strings.Repeat("0", 9-len(nanoDigits))
+		nsec, _ = strconv.ParseInt(nanoDigits, 10, 64)
+	}
+	if neg {
+		sec, nsec = -sec, -nsec
+	}
+	return time.Unix(sec, nsec).UTC(), nil
+}
+
+// formatTime returns t as a JSON value with layout, Unix layouts are numbers, others are strings.
+func formatTime(t time.Time, layout string) []byte {
+	switch layout {
+	case LayoutUnix:
+		return strconv.AppendInt(nil, t.Unix(), 10)
+	case LayoutUnixMilli:
+		return strconv.AppendInt(nil, t.UnixMilli(), 10)
+	case "":
+		layout = time.RFC3339Nano
+	}
+	return appendQuoted(nil, t.Format(layout), false)
+}
+
+// parseDuration converts a Go duration string like "1h30m" to time.Duration.
+func parseDuration(val string) (time.Duration, error) {
+	d, err := time.ParseDuration(val)
+	if err != nil {
+		return 0, durationParseError(val)
+	}
+	return d, nil
+}
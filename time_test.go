@@ -0,0 +1,161 @@
+package jin
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestUnixTime(t *testing.T) {
+	tests := []struct {
+		val  string
+		unit time.Duration
+		sec  int64
+		nsec int64
+		err  error
+	}{
+		{"1700000000", time.Second, 1700000000, 0, nil},
+		{"1700000000.123456789", time.Second, 1700000000, 123456789, nil},
+		{"1700000000.1234567899", time.Second, 1700000000, 123456789, nil},
+		{"1700000000.25", time.Second, 1700000000, 250000000, nil},
+		{"1.7e9", time.Second, 1700000000, 0, nil},
+		{"17000000001234e-4", time.Second, 1700000000, 123400000, nil},
+		{"0.000000001", time.Second, 0, 1, nil},
+		{"1e-10", time.Second, 0, 0, nil},
+		{"0", time.Second, 0, 0, nil},
+		{"-0.0", time.Second, 0, 0, nil},
+		{"0e5000", time.Second, 0, 0, nil},
+		{"-1.5", time.Second, -2, 500000000, nil},
+		{"1e-5000", time.Second, 0, 0, nil},
+		{"1700000000123", time.Millisecond, 1700000000, 123000000, nil},
+		{"1700000000123.456789", time.Millisecond, 1700000000, 123456789, nil},
+		{"1.5", time.Millisecond, 0, 1500000, nil},
+		{"-1", time.Millisecond, -1, 999000000, nil},
+		{"9223372036854775807", time.Second, 9223372036854775807, 0, nil},
+		{"9223372036854775808", time.Second, 0, 0, ErrNumberOverflow},
+		{"1e19", time.Second, 0, 0, ErrNumberOverflow},
+		{"1e99999999999999999999", time.Second, 0, 0, ErrNumberOverflow},
+		{"", time.Second, 0, 0, ErrTimeParse},
+		{"abc", time.Second, 0, 0, ErrTimeParse},
+		{"1.", time.Second, 0, 0, ErrTimeParse},
+		{"01", time.Second, 0, 0, ErrTimeParse},
+	}
+	for _, test := range tests {
+		got, err := unixTime(test.val, test.unit)
+		if test.err != nil {
+			if !errors.Is(err, test.err) {
+				t.Errorf("%q: got %v, want %v", test.val, err, test.err)
+			}
+			continue
+		}
+		if err != nil || got.Unix() != test.sec || int64(got.Nanosecond()) != test.nsec || got.Location() != time.UTC {
+			t.Errorf("%q: got %v %v %v, want %v %v", test.val, got.Unix(), got.Nanosecond(), err, test.sec, test.nsec)
+		}
+	}
+}
+
+func TestGetTime(t *testing.T) {
+	json := []byte(`{"rfc":"2023-11-14T22:13:20.5Z","date":"2023-11-14","unix":1700000000.123456789,` +
+		`"quoted":"1700000000","milli":1700000000123,"bad":"yesterday","num":12}`)
+	pars, err := Parse(json)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := time.Unix(1700000000, 0).UTC()
+	tests := []struct {
+		key    string
+		layout string
+		want   time.Time
+		err    error
+	}{
+		{"rfc", "", base.Add(500 * time.Millisecond), nil},
+		{"rfc", time.RFC3339, base.Add(500 * time.Millisecond), nil},
+		{"date", "2006-01-02", time.Date(2023, 11, 14, 0, 0, 0, 0, time.UTC), nil},
+		{"unix", LayoutUnix, base.Add(123456789), nil},
+		{"quoted", LayoutUnix, base, nil},
+		{"milli", LayoutUnixMilli, base.Add(123 * time.Millisecond), nil},
+		{"date", "", time.Time{}, ErrTimeParse},
+		{"bad", LayoutUnix, time.Time{}, ErrTimeParse},
+		{"num", time.RFC3339, time.Time{}, ErrTimeParse},
+		{"missing", "", time.Time{}, ErrKeyNotFound},
+	}
+	for _, test := range tests {
+		got, err := GetTime(json, test.layout, test.key)
+		parsGot, parsErr := pars.GetTime(test.layout, test.key)
+		if test.err != nil {
+			if !errors.Is(err, test.err) || !errors.Is(parsErr, test.err) {
+				t.Errorf("%v %q: got %v and %v, want %v", test.key, test.layout, err, parsErr, test.err)
+			}
+			continue
+		}
+		if err != nil || parsErr != nil || !got.Equal(test.want) || !parsGot.Equal(test.want) {
+			t.Errorf("%v %q: got %v %v and %v %v, want %v", test.key, test.layout, got, err, parsGot, parsErr, test.want)
+		}
+	}
+}
+
+func TestSetTime(t *testing.T) {
+	value := time.Date(2023, 11, 14, 22, 13, 20, 123456789, time.UTC)
+	tests := []struct {
+		layout string
+		want   string
+	}{
+		{"", `{"t":"2023-11-14T22:13:20.123456789Z"}`},
+		{time.RFC3339, `{"t":"2023-11-14T22:13:20Z"}`},
+		{"2006-01-02", `{"t":"2023-11-14"}`},
+		{LayoutUnix, `{"t":1700000000}`},
+		{LayoutUnixMilli, `{"t":1700000000123}`},
+	}
+	for _, test := range tests {
+		got, err := SetTime([]byte(`{"t":0}`), value, test.layout, "t")
+		if err != nil || string(got) != test.want {
+			t.Errorf("%q: got %s %v, want %s", test.layout, got, err, test.want)
+		}
+		pars, err := Parse([]byte(`{"t":0}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := pars.SetTime(value, test.layout, "t"); err != nil {
+			t.Errorf("%q: Parser.SetTime returned %v", test.layout, err)
+		}
+		if parsGot, _ := pars.Get(); string(parsGot) != test.want {
+			t.Errorf("%q: Parser.SetTime got %s, want %s", test.layout, parsGot, test.want)
+		}
+	}
+}
+
+func TestGetDuration(t *testing.T) {
+	json := []byte(`{"d":"1h30m","ms":"250ms","neg":"-1.5s","zero":"0","num":90,"unit":"10","bad":"1x","empty":""}`)
+	pars, err := Parse(json)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		key  string
+		want time.Duration
+		err  error
+	}{
+		{"d", 90 * time.Minute, nil},
+		{"ms", 250 * time.Millisecond, nil},
+		{"neg", -1500 * time.Millisecond, nil},
+		{"zero", 0, nil},
+		{"num", 0, ErrDurationParse},
+		{"unit", 0, ErrDurationParse},
+		{"bad", 0, ErrDurationParse},
+		{"empty", 0, ErrDurationParse},
+		{"missing", 0, ErrKeyNotFound},
+	}
+	for _, test := range tests {
+		got, err := GetDuration(json, test.key)
+		parsGot, parsErr := pars.GetDuration(test.key)
+		if test.err != nil {
+			if !errors.Is(err, test.err) || !errors.Is(parsErr, test.err) {
+				t.Errorf("%v: got %v and %v, want %v", test.key, err, parsErr, test.err)
+			}
+			continue
+		}
+		if err != nil || parsErr != nil || got != test.want || parsGot != test.want {
+			t.Errorf("%v: got %v %v and %v %v, want %v", test.key, got, err, parsGot, parsErr, test.want)
+		}
+	}
+}
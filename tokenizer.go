@@ -0,0 +1,251 @@
+package jin
+
+import (
+	"io"
+	"strconv"
+)
+
+// TokenKind is the kind of a Token.
+type TokenKind int
+
+// Kinds of tokens, TokenLiteral is for true, false and null.
+const (
+	TokenBeginObject TokenKind = iota + 1
+	TokenEndObject
+	TokenBeginArray
+	TokenEndArray
+	TokenKey
+	TokenString
+	TokenNumber
+	TokenLiteral
+)
+
+// String returns the name of kind, like "BeginObject" or "Key".
+func (k TokenKind) String() string {
+	switch k {
+	case TokenBeginObject:
+		return "BeginObject"
+	case TokenEndObject:
+		return "EndObject"
+	case TokenBeginArray:
+		return "BeginArray"
+	case TokenEndArray:
+		return "EndArray"
+	case TokenKey:
+		return "Key"
+	case TokenString:
+		return "String"
+	case TokenNumber:
+		return "Number"
+	case TokenLiteral:
+		return "Literal"
+	}
+	return "Invalid"
+}
+
+// Token is an event of Tokenizer.
+// Start and End are the byte offsets of the token, strings and keys include their quotation marks,
+// so json[Start:End] is always the token itself and Value is that slice.
+// Path is the path of the value, for keys it is the path of the member, for end tokens the path of the container.
+// Keys of the path are decoded like GetKeys() returns them, so they can be given to any function that takes a path.
+// Path is shared between tokens, it must be copied to keep it after the next token.
+type Token struct {
+	Kind  TokenKind
+	Start int
+	End   int
+	Value []byte
+	Path  []string
+}
+
+// Text returns the decoded text of String and Key tokens, raw text of other tokens.
+func (tok Token) Text() string {
+	if tok.Kind == TokenString || tok.Kind == TokenKey {
+		return string(unescape(tok.Value[1 : len(tok.Value)-1]))
+	}
+	return string(tok.Value)
+}
+
+// states of a container in Tokenizer.
+const (
+	// after the opening brace, a member or the closing brace.
+	frameFirst = iota
+	// after a value, a comma or the closing brace.
+	frameNext
+	// after a comma, a member must follow.
+	frameMember
+	// after a key, a colon and a value.
+	frameColon
+)
+
+type frame struct {
+	brace byte
+	index int
+	state int
+}
+
+// Tokenizer is a SAX-style tokenizer, it reads a JSON document as a flat sequence of tokens.
+// It validates the document strictly like ValidateDetailed() while reading,
+// so custom processors like redactors or indexers can be built without tracking quotes and escapes.
+//
+//	tokenizer := jin.NewTokenizer(json)
+//	for {
+//		tok, err := tokenizer.Next()
+//		if err == io.EOF {
+//			break
+//		}
+//		...
+//	}
+type Tokenizer struct {
+	json    []byte
+	pos     int
+	stack   []frame
+	path    []string
+	started bool
+}
+
+// NewTokenizer returns a Tokenizer that reads json.
+func NewTokenizer(json []byte) *Tokenizer {
+	return &Tokenizer{json: json, stack: make([]frame, 0, 8), path: make([]string, 0, 8)}
+}
+
+// Tokenize calls callback for every token of json in document order, callback returns false to stop.
+func Tokenize(json []byte, callback func(tok Token) bool) error {
+	tokenizer := NewTokenizer(json)
+	for {
+		tok, err := tokenizer.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !callback(tok) {
+			return nil
+		}
+	}
+}
+
+// Next returns the next token, it returns io.EOF after the last token.
+// Errors are *Error values with code ErrBadJSON, like ValidateDetailed() returns.
+func (t *Tokenizer) Next() (Token, error) {
+	json := t.json
+	i := skipSpace(json, t.pos)
+	if len(t.stack) == 0 {
+		if t.started {
+			if i != len(json) {
+				return Token{}, badJSONError(json, i, "end of input")
+			}
+			return Token{}, io.EOF
+		}
+		t.started = true
+		return t.value(i)
+	}
+	f := &t.stack[len(t.stack)-1]
+	// 93 = ], 125 = }
+	closing := f.brace + 2
+	switch f.state {
+	case frameFirst, frameNext:
+		if i < len(json) && json[i] == closing {
+			return t.end(i)
+		}
+		if f.state == frameNext {
+			// 44 = ,
+			if i >= len(json) || json[i] != 44 {
+				return Token{}, badJSONError(json, i, "',' or '"+string(closing)+"'")
+			}
+			i = skipSpace(json, i+1)
+		}
+		f.state = frameMember
+	case frameColon:
+		// 58 = :
+		if i >= len(json) || json[i] != 58 {
+			return Token{}, badJSONError(json, i, "':'")
+		}
+		i = skipSpace(json, i+1)
+	}
+	if f.state == frameMember {
+		// 123 = {
+		if f.brace == 123 {
+			// 34 = "
+			if i >= len(json) || json[i] != 34 {
+				return Token{}, badJSONError(json, i, "'\"'")
+			}
+			end, err := validateString(json, i)
+			if err != nil {
+				return Token{}, err
+			}
+			t.path[len(t.path)-1] = string(unescape(json[i+1 : end-1]))
+			f.state = frameColon
+			t.pos = end
+			return Token{Kind: TokenKey, Start: i, End: end, Value: json[i:end], Path: t.path}, nil
+		}
+		t.path[len(t.path)-1] = strconv.Itoa(f.index)
+	}
+	f.index++
+	f.state = frameNext
+	return t.value(i)
+}
+
+// value reads the value that starts at json[i], it opens a container for objects and arrays.
+func (t *Tokenizer) value(i int) (Token, error) {
+	json := t.json
+	if i >= len(json) {
+		return Token{}, badJSONError(json, i, "value")
+	}
+	var kind TokenKind
+	var end int
+	var err error
+	switch curr := json[i]; {
+	// 123 = {, 91 = [
+	case curr == 123 || curr == 91:
+		t.stack = append(t.stack, frame{brace: curr, state: frameFirst})
+		t.path = append(t.path, "")
+		t.pos = i + 1
+		kind = TokenBeginArray
+		if curr == 123 {
+			kind = TokenBeginObject
+		}
+		return Token{Kind: kind, Start: i, End: i + 1, Value: json[i : i+1], Path: t.path[:len(t.path)-1]}, nil
+	// 34 = "
+	case curr == 34:
+		kind = TokenString
+		end, err = validateString(json, i)
+	// 45 = -, 48-57 = digits
+	case curr == 45 || isDigit(curr):
+		kind = TokenNumber
+		end, err = validateNumber(json, i)
+	// 116 = t
+	case curr == 116:
+		kind = TokenLiteral
+		end, err = validateLiteral(json, i, "true")
+	// 102 = f
+	case curr == 102:
+		kind = TokenLiteral
+		end, err = validateLiteral(json, i, "false")
+	// 110 = n
+	case curr == 110:
+		kind = TokenLiteral
+		end, err = validateLiteral(json, i, "null")
+	default:
+		return Token{}, badJSONError(json, i, "value")
+	}
+	if err != nil {
+		return Token{}, err
+	}
+	t.pos = end
+	return Token{Kind: kind, Start: i, End: end, Value: json[i:end], Path: t.path}, nil
+}
+
+// end closes the container at the top of the stack, json[i] is the closing brace.
+func (t *Tokenizer) end(i int) (Token, error) {
+	brace := t.stack[len(t.stack)-1].brace
+	t.stack = t.stack[:len(t.stack)-1]
+	t.path = t.path[:len(t.path)-1]
+	t.pos = i + 1
+	kind := TokenEndArray
+	// 123 = {
+	if brace == 123 {
+		kind = TokenEndObject
+	}
+	return Token{Kind: kind, Start: i, End: i + 1, Value: t.json[i : i+1], Path: t.path}, nil
+}
@@ -0,0 +1,152 @@
+package jin
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	json := []byte(` {"a" : [1, "x\"y", {}], "b\u0063": {"d": [[]]}, "e": null} `)
+	expected := []string{
+		"BeginObject  {",
+		"Key a \"a\"",
+		"BeginArray a [",
+		"Number a.0 1",
+		"String a.1 \"x\\\"y\"",
+		"BeginObject a.2 {",
+		"EndObject a.2 }",
+		"EndArray a ]",
+		"Key bc \"b\\u0063\"",
+		"BeginObject bc {",
+		"Key bc.d \"d\"",
+		"BeginArray bc.d [",
+		"BeginArray bc.d.0 [",
+		"EndArray bc.d.0 ]",
+		"EndArray bc.d ]",
+		"EndObject bc }",
+		"Key e \"e\"",
+		"Literal e null",
+		"EndObject  }",
+	}
+	got := make([]string, 0, len(expected))
+	err := Tokenize(json, func(tok Token) bool {
+		if string(json[tok.Start:tok.End]) != string(tok.Value) {
+			t.Errorf("%v: value %q is not json[%v:%v]", tok.Kind, tok.Value, tok.Start, tok.End)
+		}
+		got = append(got, tok.Kind.String()+" "+strings.Join(tok.Path, ".")+" "+string(tok.Value))
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("got\n%v\nwant\n%v", strings.Join(got, "\n"), strings.Join(expected, "\n"))
+	}
+}
+
+func TestTokenizeScalars(t *testing.T) {
+	tests := []struct {
+		json string
+		kind TokenKind
+		text string
+	}{
+		{`"a\nb"`, TokenString, "a\nb"},
+		{` -1.5e3 `, TokenNumber, "-1.5e3"},
+		{`true`, TokenLiteral, "true"},
+		{`false`, TokenLiteral, "false"},
+		{`null`, TokenLiteral, "null"},
+	}
+	for _, test := range tests {
+		tokenizer := NewTokenizer([]byte(test.json))
+		tok, err := tokenizer.Next()
+		if err != nil || tok.Kind != test.kind || tok.Text() != test.text || len(tok.Path) != 0 {
+			t.Errorf("%v: got %v %q %v %v", test.json, tok.Kind, tok.Text(), tok.Path, err)
+		}
+		if _, err := tokenizer.Next(); err != io.EOF {
+			t.Errorf("%v: got %v, want io.EOF", test.json, err)
+		}
+	}
+}
+
+func TestTokenizeStop(t *testing.T) {
+	count := 0
+	err := Tokenize([]byte(`[1,2,3,`), func(tok Token) bool {
+		count++
+		return tok.Kind != TokenNumber
+	})
+	if err != nil || count != 2 {
+		t.Errorf("got %v tokens %v, want 2 tokens and no error", count, err)
+	}
+}
+
+func TestTokenizeErrors(t *testing.T) {
+	tests := []string{
+		``,
+		`   `,
+		`{`,
+		`[`,
+		`[1,]`,
+		`[1 2]`,
+		`[1}`,
+		`{"a":1,}`,
+		`{"a" 1}`,
+		`{"a":}`,
+		`{1:2}`,
+		`{'a':1}`,
+		`{"a":1]`,
+		`]`,
+		`01`,
+		`-`,
+		`1.`,
+		`1e`,
+		`[.5]`,
+		`"\x"`,
+		`"\u12g4"`,
+		"\"a\nb\"",
+		"\"\xff\"",
+		"[\"\xed\xa0\x80\"]",
+		`"abc`,
+		`{"a\x":1}`,
+		`tru`,
+		`[nul]`,
+		`{} x`,
+		`1 2`,
+		`[] []`,
+	}
+	for _, json := range tests {
+		err := Tokenize([]byte(json), func(tok Token) bool { return true })
+		var e *Error
+		if !errors.As(err, &e) || !errors.Is(err, ErrBadJSON) {
+			t.Errorf("%q: got %v, want a bad json error", json, err)
+			continue
+		}
+		var expected *Error
+		errors.As(ValidateDetailed([]byte(json)), &expected)
+		if expected == nil || e.Offset != expected.Offset || e.Expected != expected.Expected {
+			t.Errorf("%q: got offset %v expected %q, ValidateDetailed() returns %v", json, e.Offset, e.Expected, expected)
+		}
+	}
+}
+
+func TestTokenizerErrorRepeats(t *testing.T) {
+	tokenizer := NewTokenizer([]byte(`[1,,2]`))
+	var first error
+	for i := 0; i < 4; i++ {
+		_, err := tokenizer.Next()
+		if err == nil {
+			continue
+		}
+		if first == nil {
+			first = err
+			continue
+		}
+		if err.(*Error).Offset != first.(*Error).Offset {
+			t.Errorf("got %v after %v, want the same error", err, first)
+		}
+	}
+	if first == nil {
+		t.Errorf("got no error, want a bad json error")
+	}
+}
@@ -0,0 +1,191 @@
+package jin
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIsNull(t *testing.T) {
+	json := []byte("{\"a\" : null ,\"b\":[ null\t, 1 ,\nnull\n],\"c\":\"null\",\"d\":{\"e\":null},\"f\":0,\"g\":[]}")
+	pars, err := Parse(json)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		path []string
+		want bool
+		err  error
+	}{
+		{[]string{"a"}, true, nil},
+		{[]string{"b", "0"}, true, nil},
+		{[]string{"b", "1"}, false, nil},
+		{[]string{"b", "2"}, true, nil},
+		{[]string{"b", "-1"}, true, nil},
+		{[]string{"c"}, false, nil},
+		{[]string{"d"}, false, nil},
+		{[]string{"d", "e"}, true, nil},
+		{[]string{"f"}, false, nil},
+		{[]string{"g"}, false, nil},
+		{[]string{"x"}, false, ErrKeyNotFound},
+		{[]string{"b", "3"}, false, ErrIndexOutOfRange},
+	}
+	for _, test := range tests {
+		got, err := IsNull(json, test.path...)
+		parsGot, parsErr := pars.IsNull(test.path...)
+		// Parser reports missing indexes as missing keys, so only the interpreter error is compared.
+		if test.err != nil {
+			if !errors.Is(err, test.err) || parsErr == nil {
+				t.Errorf("%v: got %v and %v, want %v", test.path, err, parsErr, test.err)
+			}
+			continue
+		}
+		if err != nil || parsErr != nil || got != test.want || parsGot != test.want {
+			t.Errorf("%v: got %v %v and %v %v, want %v", test.path, got, err, parsGot, parsErr, test.want)
+		}
+	}
+}
+
+func TestNullWriters(t *testing.T) {
+	json := `{"a":1,"b":[1,2]}`
+	tests := []struct {
+		name string
+		run  func(json []byte) ([]byte, error)
+		pars func(p *Parser) error
+		want string
+		err  error
+	}{
+		{"SetNull",
+			func(json []byte) ([]byte, error) { return SetNull(json, "a") },
+			func(p *Parser) error { return p.SetNull("a") },
+			`{"a":null,"b":[1,2]}`, nil},
+		{"SetNull index",
+			func(json []byte) ([]byte, error) { return SetNull(json, "b", "1") },
+			func(p *Parser) error { return p.SetNull("b", "1") },
+			`{"a":1,"b":[1,null]}`, nil},
+		{"SetNull missing",
+			func(json []byte) ([]byte, error) { return SetNull(json, "x") },
+			func(p *Parser) error { return p.SetNull("x") },
+			``, ErrKeyNotFound},
+		{"AddNull",
+			func(json []byte) ([]byte, error) { return AddNull(json, "b") },
+			func(p *Parser) error { return p.AddNull("b") },
+			`{"a":1,"b":[1,2,null]}`, nil},
+		{"AddNull not array",
+			func(json []byte) ([]byte, error) { return AddNull(json, "a") },
+			func(p *Parser) error { return p.AddNull("a") },
+			``, ErrArrayExpected},
+		{"InsertNull",
+			func(json []byte) ([]byte, error) { return InsertNull(json, 0, "b") },
+			func(p *Parser) error { return p.InsertNull(0, "b") },
+			`{"a":1,"b":[null,1,2]}`, nil},
+		{"InsertNull last",
+			func(json []byte) ([]byte, error) { return InsertNull(json, -1, "b") },
+			func(p *Parser) error { return p.InsertNull(-1, "b") },
+			`{"a":1,"b":[1,null,2]}`, nil},
+		{"InsertNull out of range",
+			func(json []byte) ([]byte, error) { return InsertNull(json, 5, "b") },
+			func(p *Parser) error { return p.InsertNull(5, "b") },
+			``, ErrIndexOutOfRange},
+		{"AddKeyValueNull",
+			func(json []byte) ([]byte, error) { return AddKeyValueNull(json, "c") },
+			func(p *Parser) error { return p.AddKeyValueNull("c") },
+			`{"a":1,"b":[1,2],"c":null}`, nil},
+		{"AddKeyValueNull existing",
+			func(json []byte) ([]byte, error) { return AddKeyValueNull(json, "a") },
+			func(p *Parser) error { return p.AddKeyValueNull("a") },
+			``, ErrKeyAlreadyExists},
+		{"AddKeyValueNull empty key",
+			func(json []byte) ([]byte, error) { return AddKeyValueNull(json, "") },
+			func(p *Parser) error { return p.AddKeyValueNull("") },
+			``, ErrNullKey},
+	}
+	for _, test := range tests {
+		got, err := test.run([]byte(json))
+		pars, perr := Parse([]byte(json))
+		if perr != nil {
+			t.Fatal(perr)
+		}
+		parsErr := test.pars(pars)
+		// Parser reports a non array value as bad json, so only the interpreter error is compared.
+		if test.err != nil {
+			if !errors.Is(err, test.err) || parsErr == nil {
+				t.Errorf("%v: got %v and %v, want %v", test.name, err, parsErr, test.err)
+			}
+			continue
+		}
+		parsGot, _ := pars.Get()
+		if err != nil || parsErr != nil || string(got) != test.want || string(parsGot) != test.want {
+			t.Errorf("%v: got %s %v and %s %v, want %v", test.name, got, err, parsGot, parsErr, test.want)
+		}
+	}
+}
+
+func TestKindOf(t *testing.T) {
+	tests := []struct {
+		value string
+		want  Kind
+	}{
+		{`{}`, KindObject},
+		{`[]`, KindArray},
+		{`""`, KindString},
+		{`"null"`, KindString},
+		{`0`, KindNumber},
+		{`-1`, KindNumber},
+		{`9e9`, KindNumber},
+		{`true`, KindBool},
+		{`false`, KindBool},
+		{`null`, KindNull},
+		{``, KindInvalid},
+		{`+1`, KindInvalid},
+		{`.5`, KindInvalid},
+		{`x`, KindInvalid},
+	}
+	for _, test := range tests {
+		if got := kindOf([]byte(test.value)); got != test.want {
+			t.Errorf("%q: got %v, want %v", test.value, got, test.want)
+		}
+	}
+	if KindInvalid.String() != "invalid" || Kind(99).String() != "invalid" || KindNull.String() != "null" {
+		t.Errorf("unexpected kind names %v %v %v", KindInvalid, Kind(99), KindNull)
+	}
+}
+
+func TestGetKind(t *testing.T) {
+	json := []byte("{\"o\" : { } ,\"a\":[ 1 , \"s\" ,\ttrue , null , -2.5 ],\"s\":\"true\",\"n\": null ,\"b\":false}")
+	pars, err := Parse(json)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		path []string
+		want Kind
+		err  error
+	}{
+		{[]string{"o"}, KindObject, nil},
+		{[]string{"a"}, KindArray, nil},
+		{[]string{"a", "0"}, KindNumber, nil},
+		{[]string{"a", "1"}, KindString, nil},
+		{[]string{"a", "2"}, KindBool, nil},
+		{[]string{"a", "3"}, KindNull, nil},
+		{[]string{"a", "4"}, KindNumber, nil},
+		{[]string{"s"}, KindString, nil},
+		{[]string{"n"}, KindNull, nil},
+		{[]string{"b"}, KindBool, nil},
+		{[]string{"x"}, KindInvalid, ErrKeyNotFound},
+		{[]string{"a", "5"}, KindInvalid, ErrIndexOutOfRange},
+	}
+	for _, test := range tests {
+		got, err := GetKind(json, test.path...)
+		parsGot, parsErr := pars.GetKind(test.path...)
+		// Parser reports missing indexes as missing keys, so only the interpreter error is compared.
+		if test.err != nil {
+			if !errors.Is(err, test.err) || parsErr == nil {
+				t.Errorf("%v: got %v and %v, want %v", test.path, err, parsErr, test.err)
+			}
+			continue
+		}
+		if err != nil || parsErr != nil || got != test.want || parsGot != test.want {
+			t.Errorf("%v: got %v %v and %v %v, want %v", test.path, got, err, parsGot, parsErr, test.want)
+		}
+	}
+}
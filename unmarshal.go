@@ -0,0 +1,712 @@
+package jin
+
+import (
+	"encoding"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	numberType   = reflect.TypeOf(Number(""))
+	bigIntType   = reflect.TypeOf(big.Int{})
+	bigFloatType = reflect.TypeOf(big.Float{})
+
+	unmarshalerType     = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// Unmarshaler is the interface of types that can read themselves from JSON.
+// It is same with json.Unmarshaler of the standard library, so types that implement it work with Unmarshal() too.
+type Unmarshaler interface {
+	UnmarshalJSON([]byte) error
+}
+
+// Unmarshal decodes json to the value that v points to, in a single pass over the input.
+// Fields of structs are matched with 'jin' tags, a tag is a path like ParsePath() takes,
+// so nested values can be read without declaring nested structs.
+//
+//	type Repo struct {
+//		Name   string            `jin:"repo.name"`
+//		Owner  string            `jin:"repo.owner.login"`
+//		First  string            `jin:"tags[0]"`
+//		Tags   []string          `jin:"tags"`
+//		Labels map[string]string `jin:"labels"`
+//		Cache  int               `jin:"-"`
+//	}
+//
+// Fields without a 'jin' tag use their 'json' tag like encoding/json does, so existing structs work as they are.
+// Names of 'json' tags are single keys, not paths, so `json:"a.b"` matches the key "a.b".
+// Fields without a tag match with the key that has the same name, case insensitively.
+// Fields of embedded structs are promoted, unexported fields and fields tagged with '-' are skipped.
+// Pointers, structs, slices, arrays, maps with string keys, interface{}, Number, big.Int and big.Float
+// values are supported, values that implement Unmarshaler or encoding.TextUnmarshaler decode themselves.
+// interface{} values are decoded to map[string]interface{}, []interface{}, string, float64, bool or nil.
+// []byte values are decoded from base64 strings like GetBytes() does.
+// Numbers and booleans in quotation marks are accepted like GetInt() and GetBool() accepts them,
+// string fields accept any value like GetString() does, objects and arrays as their JSON text.
+// null sets pointers, slices, maps and interfaces to nil, other values stay unchanged.
+// Values that no field matches are skipped, they are still validated like ValidateDetailed() does.
+// Errors are *Error values, Segment of the error is the field that failed, like 'Tags[2]' or 'Owner.Name'.
+func Unmarshal(json []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return badTargetError(fmt.Sprintf("%T", v))
+	}
+	d := decoder{json: json}
+	end, err := d.value(skipSpace(json, 0), rv.Elem(), "")
+	if err != nil {
+		return err
+	}
+	end = skipSpace(json, end)
+	if end != len(json) {
+		return badJSONError(json, end, "end of input")
+	}
+	return nil
+}
+
+// decoder is the state of an Unmarshal() call.
+type decoder struct {
+	json []byte
+}
+
+// value decodes the value that starts at json[i] to v, returns the offset after the value.
+// field is the name of the field for errors.
+func (d *decoder) value(i int, v reflect.Value, field string) (int, error) {
+	json := d.json
+	if i >= len(json) {
+		return -1, fieldError(badJSONError(json, i, "value"), field)
+	}
+	// 110 = n
+	if json[i] == 110 {
+		end, err := validateLiteral(json, i, "null")
+		if err != nil {
+			return -1, fieldError(err, field)
+		}
+		switch v.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+			v.Set(reflect.Zero(v.Type()))
+		}
+		return end, nil
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return d.value(i, v.Elem(), field)
+	case reflect.Interface:
+		if v.NumMethod() != 0 {
+			return -1, unmarshalTypeError(kindOf(json[i:]), v.Type().String()).at(i, field)
+		}
+		val, end, err := d.any(i, field)
+		if err != nil {
+			return -1, err
+		}
+		if val == nil {
+			v.Set(reflect.Zero(v.Type()))
+		} else {
+			v.Set(reflect.ValueOf(val))
+		}
+		return end, nil
+	}
+	if v.Type() != bigIntType && v.Type() != bigFloatType && v.CanAddr() {
+		if ptr := v.Addr(); ptr.Type().Implements(unmarshalerType) || ptr.Type().Implements(textUnmarshalerType) {
+			return d.unmarshaler(i, ptr, field)
+		}
+	}
+	var end int
+	var err error
+	switch json[i] {
+	// 123 = {, 91 = [
+	case 123, 91:
+		switch {
+		case v.Kind() == reflect.Map && json[i] == 123:
+			end, err = d.mapValue(i, v, field)
+		case v.Kind() == reflect.Slice && json[i] == 91:
+			end, err = d.slice(i, v, field)
+		case v.Kind() == reflect.Array && json[i] == 91:
+			end, err = d.array(i, v, field)
+		case v.Kind() == reflect.String && v.Type() != numberType:
+			end, err = d.skip(i)
+			if err == nil {
+				v.SetString(string(json[i:end]))
+			}
+		case v.Kind() == reflect.Struct && v.Type() != bigIntType && v.Type() != bigFloatType:
+			var plan *planNode
+			plan, err = typePlan(v.Type())
+			if err != nil {
+				return -1, err
+			}
+			end, err = d.fields(i, v, plan, field)
+		default:
+			return -1, unmarshalTypeError(kindOf(json[i:]), v.Type().String()).at(i, field)
+		}
+	default:
+		end, err = d.scalar(i, v, field)
+	}
+	if err != nil {
+		return -1, fieldError(err, field)
+	}
+	return end, nil
+}
+
+// scalar decodes a string, number or boolean that starts at json[i] to v.
+func (d *decoder) scalar(i int, v reflect.Value, field string) (int, error) {
+	json := d.json
+	kind := kindOf(json[i:])
+	var end int
+	var err error
+	switch kind {
+	case KindString:
+		end, err = validateString(json, i)
+	case KindNumber:
+		end, err = validateNumber(json, i)
+	case KindBool:
+		// 116 = t
+		if json[i] == 116 {
+			end, err = validateLiteral(json, i, "true")
+		} else {
+			end, err = validateLiteral(json, i, "false")
+		}
+	default:
+		return -1, badJSONError(json, i, "value")
+	}
+	if err != nil {
+		return -1, err
+	}
+	var val string
+	if kind == KindString {
+		val = string(unescape(json[i+1 : end-1]))
+	} else {
+		val = string(json[i:end])
+	}
+	if err := setScalar(v, kind, val); err != nil {
+		return -1, err.at(i, field)
+	}
+	return end, nil
+}
+
+// setScalar sets v to val, val is a number, a boolean or a decoded string that kind tells.
+func setScalar(v reflect.Value, kind Kind, val string) *Error {
+	typeName := v.Type().String()
+	switch v.Type() {
+	case numberType:
+		if !isNumber(val) {
+			return numberParseError(val)
+		}
+		v.SetString(val)
+		return nil
+	case bigIntType:
+		num, err := Number(val).BigInt()
+		if err != nil {
+			return err.(*Error)
+		}
+		v.Set(reflect.ValueOf(num).Elem())
+		return nil
+	case bigFloatType:
+		num, err := Number(val).BigFloat()
+		if err != nil {
+			return err.(*Error)
+		}
+		v.Set(reflect.ValueOf(num).Elem())
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(val)
+	case reflect.Slice:
+		if kind != KindString || v.Type().Elem().Kind() != reflect.Uint8 {
+			return unmarshalTypeError(kind, typeName)
+		}
+		data, err := decodeBase64(val)
+		if err != nil {
+			return err.(*Error)
+		}
+		v.SetBytes(data)
+	case reflect.Bool:
+		if !isBool(val) {
+			return boolParseError(val)
+		}
+		v.SetBool(val == "true")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		num, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return rangeError(err, val, typeName, intParseError(val))
+		}
+		if v.OverflowInt(num) {
+			return numberOverflowError(val, typeName)
+		}
+		v.SetInt(num)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		num, err := Number(val).Uint64()
+		if err != nil {
+			e := err.(*Error)
+			if e.Code == ErrNumberOverflow.Code {
+				return numberOverflowError(val, typeName)
+			}
+			return e
+		}
+		if v.OverflowUint(num) {
+			return numberOverflowError(val, typeName)
+		}
+		v.SetUint(num)
+	case reflect.Float32, reflect.Float64:
+		num, err := strconv.ParseFloat(val, v.Type().Bits())
+		if err != nil {
+			return rangeError(err, val, typeName, floatParseError(val))
+		}
+		v.SetFloat(num)
+	default:
+		return unmarshalTypeError(kind, typeName)
+	}
+	return nil
+}
+
+// unmarshaler decodes the value that starts at json[i] with the methods of ptr.
+// Unmarshaler takes the raw value, encoding.TextUnmarshaler takes the decoded string.
+func (d *decoder) unmarshaler(i int, ptr reflect.Value, field string) (int, error) {
+	json := d.json
+	if u, ok := ptr.Interface().(Unmarshaler); ok {
+		end, err := d.skip(i)
+		if err != nil {
+			return -1, fieldError(err, field)
+		}
+		if err := u.UnmarshalJSON(json[i:end]); err != nil {
+			return -1, unmarshalerError(ptr.Type().String(), err).at(i, field)
+		}
+		return end, nil
+	}
+	// 34 = "
+	if json[i] != 34 {
+		return -1, unmarshalTypeError(kindOf(json[i:]), ptr.Elem().Type().String()).at(i, field)
+	}
+	end, err := validateString(json, i)
+	if err != nil {
+		return -1, fieldError(err, field)
+	}
+	if err := ptr.Interface().(encoding.TextUnmarshaler).UnmarshalText(unescape(json[i+1 : end-1])); err != nil {
+		return -1, unmarshalerError(ptr.Type().String(), err).at(i, field)
+	}
+	return end, nil
+}
+
+// any decodes the value that starts at json[i] as interface{}.
+func (d *decoder) any(i int, field string) (interface{}, int, error) {
+	json := d.json
+	switch kindOf(json[i:]) {
+	case KindObject:
+		obj := make(map[string]interface{})
+		end, err := d.children(i, func(label string, start int) (int, error) {
+			val, end, err := d.any(start, field+"["+label+"]")
+			obj[label] = val
+			return end, err
+		})
+		if err != nil {
+			return nil, -1, fieldError(err, field)
+		}
+		return obj, end, nil
+	case KindArray:
+		arr := make([]interface{}, 0, 4)
+		end, err := d.children(i, func(label string, start int) (int, error) {
+			val, end, err := d.any(start, field+"["+label+"]")
+			arr = append(arr, val)
+			return end, err
+		})
+		if err != nil {
+			return nil, -1, fieldError(err, field)
+		}
+		return arr, end, nil
+	case KindNull:
+		end, err := validateLiteral(json, i, "null")
+		if err != nil {
+			return nil, -1, fieldError(err, field)
+		}
+		return nil, end, nil
+	case KindString:
+		var str string
+		end, err := d.scalar(i, reflect.ValueOf(&str).Elem(), field)
+		return str, end, err
+	case KindBool:
+		var b bool
+		end, err := d.scalar(i, reflect.ValueOf(&b).Elem(), field)
+		return b, end, err
+	}
+	var num float64
+	end, err := d.scalar(i, reflect.ValueOf(&num).Elem(), field)
+	return num, end, err
+}
+
+// skip validates the value that starts at json[i] without decoding it, returns the offset after the value.
+func (d *decoder) skip(i int) (int, error) {
+	json := d.json
+	switch kindOf(json[i:]) {
+	case KindObject, KindArray:
+		return d.children(i, func(label string, start int) (int, error) {
+			return d.skip(start)
+		})
+	case KindString:
+		return validateString(json, i)
+	case KindNumber:
+		return validateNumber(json, i)
+	case KindNull:
+		return validateLiteral(json, i, "null")
+	case KindBool:
+		// 116 = t
+		if json[i] == 116 {
+			return validateLiteral(json, i, "true")
+		}
+		return validateLiteral(json, i, "false")
+	}
+	return -1, badJSONError(json, i, "value")
+}
+
+// fields decodes an object or an array that starts at json[i] to the fields of v, n is the plan of the level.
+func (d *decoder) fields(i int, v reflect.Value, n *planNode, field string) (int, error) {
+	return d.children(i, func(label string, start int) (int, error) {
+		child := n.lookup(label)
+		if child == nil {
+			return d.skip(start)
+		}
+		end := -1
+		for _, f := range child.fields {
+			fv, ferr := fieldByIndex(v, f.index)
+			if ferr != nil {
+				return -1, ferr.at(start, joinField(field, f.name))
+			}
+			e, err := d.value(start, fv, joinField(field, f.name))
+			if err != nil {
+				return -1, err
+			}
+			end = e
+		}
+		// 91 = [, 123 = {
+		if len(child.children) > 0 && (d.json[start] == 91 || d.json[start] == 123) {
+			e, err := d.fields(start, v, child, field)
+			if err != nil {
+				return -1, err
+			}
+			end = e
+		}
+		if end == -1 {
+			return d.skip(start)
+		}
+		return end, nil
+	})
+}
+
+// mapValue decodes an object that starts at json[i] to the map v.
+func (d *decoder) mapValue(i int, v reflect.Value, field string) (int, error) {
+	t := v.Type()
+	if t.Key().Kind() != reflect.String {
+		return -1, unmarshalTypeError(KindObject, t.String()).at(i, field)
+	}
+	if v.IsNil() {
+		v.Set(reflect.MakeMap(t))
+	}
+	return d.children(i, func(label string, start int) (int, error) {
+		elem := reflect.New(t.Elem()).Elem()
+		end, err := d.value(start, elem, field+"["+label+"]")
+		if err != nil {
+			return -1, err
+		}
+		v.SetMapIndex(reflect.ValueOf(label).Convert(t.Key()), elem)
+		return end, nil
+	})
+}
+
+// slice decodes an array that starts at json[i] to the slice v.
+func (d *decoder) slice(i int, v reflect.Value, field string) (int, error) {
+	t := v.Type()
+	slice := reflect.MakeSlice(t, 0, 4)
+	end, err := d.children(i, func(label string, start int) (int, error) {
+		slice = reflect.Append(slice, reflect.Zero(t.Elem()))
+		return d.value(start, slice.Index(slice.Len()-1), field+"["+label+"]")
+	})
+	if err != nil {
+		return -1, err
+	}
+	v.Set(slice)
+	return end, nil
+}
+
+// array decodes an array that starts at json[i] to the array v.
+// Extra elements are skipped, missing elements are set to zero.
+func (d *decoder) array(i int, v reflect.Value, field string) (int, error) {
+	index := 0
+	end, err := d.children(i, func(label string, start int) (int, error) {
+		if index >= v.Len() {
+			return d.skip(start)
+		}
+		index++
+		return d.value(start, v.Index(index-1), field+"["+label+"]")
+	})
+	if err != nil {
+		return -1, err
+	}
+	for ; index < v.Len(); index++ {
+		v.Index(index).Set(reflect.Zero(v.Type().Elem()))
+	}
+	return end, nil
+}
+
+// children calls callback for every member of the object or every element of the array that starts at json[i].
+// Labels are decoded keys for objects and indexes for arrays,
+// callback returns the offset after the value so the value is read only once.
+// children returns the offset after the closing brace.
+func (d *decoder) children(i int, callback func(label string, start int) (int, error)) (int, error) {
+	json := d.json
+	brace := json[i]
+	index := 0
+	i = skipSpace(json, i+1)
+	// 93 = ], 125 = }
+	if i < len(json) && json[i] == brace+2 {
+		return i + 1, nil
+	}
+	for {
+		var label string
+		// 123 = {
+		if brace == 123 {
+			// 34 = "
+			if i >= len(json) || json[i] != 34 {
+				return -1, badJSONError(json, i, "'\"'")
+			}
+			keyEnd, err := validateString(json, i)
+			if err != nil {
+				return -1, err
+			}
+			label = string(unescape(json[i+1 : keyEnd-1]))
+			i = skipSpace(json, keyEnd)
+			// 58 = :
+			if i >= len(json) || json[i] != 58 {
+				return -1, badJSONError(json, i, "':'")
+			}
+			i = skipSpace(json, i+1)
+		} else {
+			label = strconv.Itoa(index)
+		}
+		end, err := callback(label, i)
+		if err != nil {
+			return -1, err
+		}
+		index++
+		i = skipSpace(json, end)
+		// 44 = ,
+		if i < len(json) && json[i] == 44 {
+			i = skipSpace(json, i+1)
+			continue
+		}
+		if i < len(json) && json[i] == brace+2 {
+			return i + 1, nil
+		}
+		return -1, badJSONError(json, i, "',' or '"+string(brace+2)+"'")
+	}
+}
+
+// fieldError sets the field of err if it is not set by a deeper level.
+func fieldError(err error, field string) error {
+	if e, ok := err.(*Error); ok && e.Segment == "" {
+		e.Segment = field
+	}
+	return err
+}
+
+func joinField(parent string, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex,
+// it also allocates nil pointers of embedded structs.
+// Nil pointers to unexported structs can not be allocated, like encoding/json it returns an error for them.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, *Error) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, embeddedPointerError(v.Type().Elem().String())
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, nil
+}
+
+// planNode is a level of the field tree of a struct type, paths of tags share their common prefixes.
+type planNode struct {
+	// fields that their path ends at this level.
+	fields []fieldPlan
+	// next levels by key or index.
+	children map[string]*planNode
+	// labels of children in declaration order of fields, for Marshal().
+	labels []string
+	// lower case names of untagged fields, they match case insensitively.
+	fold map[string]*planNode
+}
+
+type fieldPlan struct {
+	index []int
+	name  string
+	// omitEmpty is the 'omitempty' option of the tag.
+	omitEmpty bool
+	// indexed is true if the path of the field has an array index, Marshal() skips them.
+	indexed bool
+}
+
+func (n *planNode) child(label string) *planNode {
+	if n.children == nil {
+		n.children = make(map[string]*planNode)
+	}
+	c, ok := n.children[label]
+	if !ok {
+		c = &planNode{}
+		n.children[label] = c
+		n.labels = append(n.labels, label)
+	}
+	return c
+}
+
+func (n *planNode) lookup(label string) *planNode {
+	if c, ok := n.children[label]; ok {
+		return c
+	}
+	return n.fold[strings.ToLower(label)]
+}
+
+// plans caches field trees of struct types.
+var plans sync.Map
+
+// typePlan returns the field tree of struct type t.
+func typePlan(t reflect.Type) (*planNode, error) {
+	if plan, ok := plans.Load(t); ok {
+		return plan.(*planNode), nil
+	}
+	plan := &planNode{fold: make(map[string]*planNode)}
+	if err := buildPlan(t, plan, nil, map[reflect.Type]bool{t: true}); err != nil {
+		return nil, err
+	}
+	plans.Store(t, plan)
+	return plan, nil
+}
+
+// buildPlan adds the fields of t to plan, visited holds the struct types that embeds t,
+// an embedded pointer to one of them is skipped so a struct that embeds itself does not recurse forever.
+func buildPlan(t reflect.Type, plan *planNode, index []int, visited map[reflect.Type]bool) error {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		path, options, literal := fieldTag(sf.Tag)
+		if path == "-" && !literal {
+			continue
+		}
+		fieldIndex := append(append(make([]int, 0, len(index)+1), index...), i)
+		if sf.Anonymous && path == "" {
+			ft := sf.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if visited[ft] {
+					continue
+				}
+				visited[ft] = true
+				err := buildPlan(ft, plan, fieldIndex, visited)
+				delete(visited, ft)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if sf.PkgPath != "" {
+			continue
+		}
+		f := fieldPlan{index: fieldIndex, name: sf.Name, omitEmpty: hasOption(options, "omitempty")}
+		if path == "" {
+			n := plan.child(sf.Name)
+			n.fields = append(n.fields, f)
+			if _, ok := plan.fold[strings.ToLower(sf.Name)]; !ok {
+				plan.fold[strings.ToLower(sf.Name)] = n
+			}
+			continue
+		}
+		if literal {
+			n := plan.child(path)
+			n.fields = append(n.fields, f)
+			continue
+		}
+		segments, err := parsePath(path)
+		if err != nil {
+			return fieldError(err, sf.Name)
+		}
+		n := plan
+		for _, s := range segments {
+			// negative indexes counts from the end, there is no end while a struct is decoded.
+			if s.recursive || (s.kind != segmentKey && s.kind != segmentIndex) || (s.kind == segmentIndex && strings.HasPrefix(s.value, "-")) {
+				return fieldError(badPathError(path, s.at), sf.Name)
+			}
+			f.indexed = f.indexed || s.kind == segmentIndex
+			n = n.child(s.value)
+		}
+		n.fields = append(n.fields, f)
+	}
+	return nil
+}
+
+// fieldTag returns the path and options of a field.
+// Fields without a 'jin' tag use their 'json' tag like encoding/json does,
+// a 'json' name is a single key so literal is true for it, "-" is a skip for both.
+func fieldTag(tag reflect.StructTag) (string, string, bool) {
+	if jinTag, ok := tag.Lookup("jin"); ok {
+		path, options := parseTag(jinTag)
+		return path, options, false
+	}
+	jsonTag := tag.Get("json")
+	if jsonTag == "-" {
+		return "-", "", false
+	}
+	name, options, _ := strings.Cut(jsonTag, ",")
+	return name, options, name != ""
+}
+
+// parseTag splits a 'jin' tag to its path and options, like "repo.name,omitempty".
+// Commas in quoted path segments are not separators.
+func parseTag(tag string) (string, string) {
+	var quote byte
+	for i := 0; i < len(tag); i++ {
+		curr := tag[i]
+		switch {
+		// 92 = \
+		case curr == 92:
+			i++
+		case quote != 0:
+			if curr == quote {
+				quote = 0
+			}
+		// 34 = ", 39 = '
+		case curr == 34 || curr == 39:
+			quote = curr
+		// 44 = ,
+		case curr == 44:
+			return tag[:i], tag[i+1:]
+		}
+	}
+	return tag, ""
+}
+
+// hasOption reports whether comma separated options of a tag has option.
+func hasOption(options string, option string) bool {
+	for _, o := range strings.Split(options, ",") {
+		if strings.TrimSpace(o) == option {
+			return true
+		}
+	}
+	return false
+}
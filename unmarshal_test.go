@@ -0,0 +1,251 @@
+package jin
+
+import (
+	"errors"
+	"testing"
+)
+
+type unexportedInner struct {
+	A int
+}
+
+type embedsUnexported struct {
+	*unexportedInner
+	B int
+}
+
+func TestUnmarshalEmbeddedUnexportedPointer(t *testing.T) {
+	var v embedsUnexported
+	if err := Unmarshal([]byte(`{"B":1}`), &v); err != nil || v.B != 1 {
+		t.Errorf("got %v %v, want B=1 and no error", v.B, err)
+	}
+	err := Unmarshal([]byte(`{"A":2,"B":1}`), &v)
+	if !errors.Is(err, ErrUnmarshalType) {
+		t.Errorf("got %v, want ErrUnmarshalType for nil embedded pointer", err)
+	}
+	v = embedsUnexported{unexportedInner: &unexportedInner{}}
+	if err := Unmarshal([]byte(`{"A":2,"B":3}`), &v); err != nil || v.A != 2 || v.B != 3 {
+		t.Errorf("got %v %v %v, want A=2 B=3 and no error", v.A, v.B, err)
+	}
+}
+
+type unmarshalRepo struct {
+	Name   string            `jin:"repo.name"`
+	Owner  string            `jin:"repo.owner.login"`
+	First  string            `jin:"tags[0]"`
+	Tags   []string          `jin:"tags"`
+	Labels map[string]string `jin:"labels"`
+	Cache  int               `jin:"-"`
+	Stars  int               `json:"stars,omitempty"`
+	Dotted string            `json:"a.b"`
+	Plain  bool
+}
+
+func TestUnmarshalPaths(t *testing.T) {
+	json := []byte(`{
+		"repo": {"name": "jin", "owner": {"login": "ecoshub"}},
+		"tags": ["json", "go"],
+		"labels": {"k": "v", "e\"q": "x"},
+		"Cache": 5,
+		"stars": "12",
+		"a.b": "dot",
+		"plain": true,
+		"unknown": [1, {"x": null}]
+	}`)
+	var r unmarshalRepo
+	if err := Unmarshal(json, &r); err != nil {
+		t.Fatal(err)
+	}
+	if r.Name != "jin" || r.Owner != "ecoshub" || r.First != "json" || len(r.Tags) != 2 || r.Tags[1] != "go" {
+		t.Errorf("paths are not decoded: %+v", r)
+	}
+	if r.Labels["k"] != "v" || r.Labels[`e"q`] != "x" {
+		t.Errorf("map is not decoded: %v", r.Labels)
+	}
+	if r.Cache != 0 || r.Stars != 12 || r.Dotted != "dot" || !r.Plain {
+		t.Errorf("tags are not applied: %+v", r)
+	}
+}
+
+type textValue struct {
+	text string
+}
+
+func (v *textValue) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("empty text")
+	}
+	v.text = string(data)
+	return nil
+}
+
+type jsonValue struct {
+	raw string
+}
+
+func (v *jsonValue) UnmarshalJSON(data []byte) error {
+	v.raw = string(data)
+	return nil
+}
+
+func TestUnmarshalValues(t *testing.T) {
+	var v struct {
+		Any    interface{}
+		Bytes  []byte
+		Num    Number
+		Arr    [2]int
+		Ptr    *int
+		Null   *int
+		Slice  []int
+		Text   textValue
+		Raw    jsonValue
+		Str    string
+		Escape string
+		Uint8  uint8
+		Float  float32
+	}
+	v.Null = new(int)
+	v.Slice = []int{1}
+	json := []byte(`{"any":{"a":[1,"x",true,null]},"bytes":"aGk=","num":1e400,"arr":[1,2,3],"ptr":7,"null":null,` +
+		`"slice":null,"text":"t","raw":{"r": 1},"str":[1, 2],"escape":"é\n","uint8":"255","float":1.5}`)
+	if err := Unmarshal(json, &v); err != nil {
+		t.Fatal(err)
+	}
+	any, ok := v.Any.(map[string]interface{})
+	if !ok || len(any["a"].([]interface{})) != 4 || any["a"].([]interface{})[0] != 1.0 {
+		t.Errorf("interface{} is not decoded: %#v", v.Any)
+	}
+	if string(v.Bytes) != "hi" || v.Num != "1e400" || v.Arr != [2]int{1, 2} || v.Ptr == nil || *v.Ptr != 7 {
+		t.Errorf("values are not decoded: %+v", v)
+	}
+	if v.Null != nil || v.Slice != nil {
+		t.Errorf("null does not reset pointers and slices: %v %v", v.Null, v.Slice)
+	}
+	if v.Text.text != "t" || v.Raw.raw != `{"r": 1}` || v.Str != "[1, 2]" || v.Escape != "é\n" || v.Uint8 != 255 || v.Float != 1.5 {
+		t.Errorf("values are not decoded: %+v", v)
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	type inner struct {
+		N int
+	}
+	type target struct {
+		Int   int
+		Int8  int8
+		Uint  uint
+		Bool  bool
+		Tags  []int
+		Inner inner
+		Map   map[int]string
+		Text  textValue
+		Raw   jsonValue
+		Bytes []byte
+	}
+	tests := []struct {
+		name    string
+		json    string
+		err     *Error
+		segment string
+		offset  int
+	}{
+		{"int from string", `{"int":"x"}`, ErrIntParse, "Int", 7},
+		{"int from float", `{"int":1.5}`, ErrIntParse, "Int", 7},
+		{"int from object", `{"int":{}}`, ErrUnmarshalType, "Int", 7},
+		{"int8 overflow", `{"int8":128}`, ErrNumberOverflow, "Int8", 8},
+		{"negative uint", `{"uint":-1}`, ErrNumberOverflow, "Uint", 8},
+		{"bad bool", `{"bool":"yes"}`, ErrBoolParse, "Bool", 8},
+		{"element", `{"tags":[1,2,"x"]}`, ErrIntParse, "Tags[2]", 13},
+		{"nested field", `{"inner":{"n":true}}`, ErrIntParse, "Inner.N", 14},
+		{"slice from object", `{"tags":{}}`, ErrUnmarshalType, "Tags", 8},
+		{"map with int keys", `{"map":{"1":"a"}}`, ErrUnmarshalType, "Map", 7},
+		{"text unmarshaler error", `{"text":""}`, ErrUnmarshalType, "Text", 8},
+		{"text from number", `{"text":1}`, ErrUnmarshalType, "Text", 8},
+		{"bad base64", `{"bytes":"!!"}`, ErrBase64, "Bytes", 9},
+		{"bad json in element", `{"tags":[1,2}`, ErrBadJSON, "Tags", 12},
+		{"trailing comma in skipped value", `{"x":[1,]}`, ErrBadJSON, "", 8},
+		{"missing colon in skipped value", `{"x":{"a"}}`, ErrBadJSON, "", 9},
+		{"bad number in skipped value", `{"x":01}`, ErrBadJSON, "", 6},
+		{"bad literal in skipped value", `{"x":nul}`, ErrBadJSON, "", 8},
+		{"control character in skipped value", "{\"x\":\"a\nb\"}", ErrBadJSON, "", 7},
+		{"bad json in unmarshaler value", `{"raw":[1,]}`, ErrBadJSON, "Raw", 10},
+		{"truncated", `{"int":1`, ErrBadJSON, "", 8},
+		{"truncated value", `{"int":`, ErrBadJSON, "Int", 7},
+		{"trailing data", `{"int":1} {}`, ErrBadJSON, "", 10},
+		{"empty", ``, ErrBadJSON, "", 0},
+	}
+	for _, test := range tests {
+		var v target
+		err := Unmarshal([]byte(test.json), &v)
+		var e *Error
+		if !errors.As(err, &e) || !errors.Is(err, test.err) {
+			t.Errorf("%v: got %v, want %v", test.name, err, test.err)
+			continue
+		}
+		if e.Segment != test.segment || e.Offset != test.offset {
+			t.Errorf("%v: got segment %q offset %v, want segment %q offset %v", test.name, e.Segment, e.Offset, test.segment, test.offset)
+		}
+	}
+}
+
+func TestUnmarshalBadTarget(t *testing.T) {
+	var v struct{}
+	var nilPtr *struct{}
+	for _, target := range []interface{}{v, nilPtr, nil} {
+		if err := Unmarshal([]byte(`{}`), target); !errors.Is(err, ErrBadTarget) {
+			t.Errorf("%T: got %v, want ErrBadTarget", target, err)
+		}
+	}
+}
+
+func TestUnmarshalBadTag(t *testing.T) {
+	var v struct {
+		A int `jin:"a..b"`
+	}
+	if err := Unmarshal([]byte(`{}`), &v); !errors.Is(err, ErrBadPath) {
+		t.Errorf("got %v, want ErrBadPath", err)
+	}
+}
+
+func TestUnmarshalNegativeIndexTag(t *testing.T) {
+	var v struct {
+		Last string `jin:"tags[-1]"`
+	}
+	err := Unmarshal([]byte(`{"tags":["a","b"]}`), &v)
+	var e *Error
+	if !errors.As(err, &e) || !errors.Is(err, ErrBadPath) {
+		t.Fatalf("got %v, want ErrBadPath", err)
+	}
+	if e.Offset != 4 {
+		t.Errorf("got offset %v, want 4", e.Offset)
+	}
+}
+
+type selfEmbedding struct {
+	*selfEmbedding
+	Name string
+}
+
+type cycleA struct {
+	*cycleB
+	A int
+}
+
+type cycleB struct {
+	*cycleA
+	B int
+}
+
+func TestUnmarshalEmbeddedCycle(t *testing.T) {
+	var s selfEmbedding
+	if err := Unmarshal([]byte(`{"Name":"x"}`), &s); err != nil || s.Name != "x" {
+		t.Errorf("got %q %v, want x", s.Name, err)
+	}
+	a := cycleA{cycleB: &cycleB{}}
+	if err := Unmarshal([]byte(`{"A":1,"B":2}`), &a); err != nil || a.A != 1 || a.B != 2 {
+		t.Errorf("got %v %v %v, want A=1 B=2", a.A, a.cycleB.B, err)
+	}
+	if _, err := Marshal(s); err != nil {
+		t.Errorf("marshal returned %v", err)
+	}
+}
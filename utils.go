@@ -1,7 +1,11 @@
 package jin
 
 import (
+	"bytes"
 	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
 	"unsafe"
 )
 
@@ -24,6 +28,16 @@ func trimSpace(str string, start, eoe int) string {
 	return str[start:end]
 }
 
+// keyMatch reports whether a raw JSON key is same with key.
+// Keys that have escape sequences also match with their decoded form.
+func keyMatch(raw string, key string) bool {
+	if raw == key {
+		return true
+	}
+	// 92 = \
+	return len(raw) > len(key) && strings.IndexByte(raw, 92) != -1 && string(unescape([]byte(raw))) == key
+}
+
 func compare(json []byte, start, end int, key string) bool {
 	if len(key) != end-start {
 		return false
@@ -83,18 +97,15 @@ func stripQuotesByte(str []byte) []byte {
 	return str
 }
 
+// formatType guesses the type of val, booleans, null, numbers, strings that has quotation marks,
+// arrays and objects returned as they are if they are valid JSON.
+// Anything else returned as a quoted and escaped JSON string.
 func formatType(val string) string {
 	if len(val) > 0 {
 		if isBool(val) {
 			return val
 		}
-		if isInt(val) {
-			if val[0] == 48 && len(val) > 1 {
-				return `"` + val + `"`
-			}
-			return val
-		}
-		if isFloat(val) {
+		if isNumber(val) {
 			return val
 		}
 		if val == "null" {
@@ -103,31 +114,117 @@ func formatType(val string) string {
 		start := val[0]
 		end := val[len(val)-1]
 		if (start == 34 && end == 34) || (start == 91 && end == 93) || (start == 123 && end == 125) {
-			return val
+			if Valid([]byte(val)) {
+				return val
+			}
 		}
-		return `"` + val + `"`
 	}
-	return `""`
+	return Quote(val)
 }
 
-func isBool(val string) bool {
-	return val == "true" || val == "false"
+// isNumber reports whether val is a number by the JSON grammar,
+// so "0123", "+1", "NaN" or "Inf" are not numbers.
+func isNumber(val string) bool {
+	// 45 = -, 48-57 = digits
+	if val[0] != 45 && !isDigit(val[0]) {
+		return false
+	}
+	end, err := validateNumber([]byte(val), 0)
+	return err == nil && end == len(val)
 }
 
-func isFloat(val string) bool {
-	_, err := strconv.ParseFloat(val, 64)
-	if err != nil {
-		return false
+// Quote returns str as a JSON string with quotation marks.
+// Quotation marks, backslashes and control characters are escaped,
+// invalid UTF-8 bytes are replaced with U+FFFD.
+// All functions that writes strings, like SetString() or MakeJsonString() use the same escaping.
+func Quote(str string) string {
+	return string(appendQuoted(make([]byte, 0, len(str)+2), str, false))
+}
+
+// QuoteHTML is a variation of Quote() func.
+// It also escapes '<', '>' and '&' characters, so the result is safe to embed in HTML.
+// It can be used with Set(), Add() or Insert() for writing HTML-safe strings.
+func QuoteHTML(str string) string {
+	return string(appendQuoted(make([]byte, 0, len(str)+2), str, true))
+}
+
+// escape returns str escaped like Quote() without quotation marks, for keys.
+func escape(str string) string {
+	quoted := appendQuoted(make([]byte, 0, len(str)+2), str, false)
+	return string(quoted[1 : len(quoted)-1])
+}
+
+// appendQuoted appends str to buf as a quoted and escaped JSON string.
+func appendQuoted(buf []byte, str string, html bool) []byte {
+	const hex = "0123456789abcdef"
+	buf = append(buf, 34)
+	start := 0
+	for i := 0; i < len(str); {
+		curr := str[i]
+		if curr < 128 {
+			// 34 = ", 92 = \, 60 = <, 62 = >, 38 = &
+			if curr >= 32 && curr != 34 && curr != 92 && (!html || (curr != 60 && curr != 62 && curr != 38)) {
+				i++
+				continue
+			}
+			buf = append(buf, str[start:i]...)
+			switch curr {
+			case 34, 92:
+				buf = append(buf, 92, curr)
+			// NL
+			case 10:
+				buf = append(buf, 92, 110)
+			// CR
+			case 13:
+				buf = append(buf, 92, 114)
+			// tab
+			case 9:
+				buf = append(buf, 92, 116)
+			// backspace
+			case 8:
+				buf = append(buf, 92, 98)
+			// form feed
+			case 12:
+				buf = append(buf, 92, 102)
+			default:
+				buf = append(buf, 92, 117, 48, 48, hex[curr>>4], hex[curr&15])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(str[i:])
+		if r == utf8.RuneError && size == 1 {
+			buf = append(buf, str[start:i]...)
+			buf = append(buf, `\ufffd`...)
+			i += size
+			start = i
+			continue
+		}
+		// U+2028 and U+2029 are valid in JSON but not in JavaScript strings.
+		if r == 8232 || r == 8233 {
+			buf = append(buf, str[start:i]...)
+			buf = append(buf, 92, 117, 50, 48, 50, hex[r&15])
+			i += size
+			start = i
+			continue
+		}
+		i += size
 	}
-	return true
+	buf = append(buf, str[start:]...)
+	return append(buf, 34)
 }
 
-func isInt(val string) bool {
-	_, err := strconv.ParseInt(val, 10, 32)
-	if err != nil {
+// isNull reports whether value starts with a null literal.
+func isNull(value []byte) bool {
+	if len(value) < 4 || string(value[:4]) != "null" {
 		return false
 	}
-	return true
+	return len(value) == 4 || space(value[4]) || value[4] == 44 || value[4] == 93 || value[4] == 125
+}
+
+func isBool(val string) bool {
+	return val == "true" || val == "false"
 }
 
 func trim(str []byte) []byte {
@@ -189,6 +286,109 @@ func cleanValue(str []byte) []byte {
 	return str[start : end+1]
 }
 
+// cleanString is a variation of cleanValue() func.
+// It also decodes escape sequences of string values, other values returned as they are.
+func cleanString(str []byte) []byte {
+	start := 0
+	end := len(str) - 1
+	for start < end && space(str[start]) {
+		start++
+	}
+	for end > start && space(str[end]) {
+		end--
+	}
+	if start < end && str[start] == 34 && str[end] == 34 {
+		return unescape(str[start+1 : end])
+	}
+	return str[start : end+1]
+}
+
+// unescape decodes escape sequences of a JSON string, str must not have quotation marks.
+// It returns str itself if there is no escape sequence in it.
+// Invalid escape sequences kept as they are, lone surrogates are replaced with U+FFFD.
+func unescape(str []byte) []byte {
+	// 92 = \
+	i := bytes.IndexByte(str, 92)
+	if i == -1 {
+		return str
+	}
+	buf := make([]byte, 0, len(str))
+	buf = append(buf, str[:i]...)
+	for i < len(str) {
+		curr := str[i]
+		if curr != 92 || i+1 == len(str) {
+			buf = append(buf, curr)
+			i++
+			continue
+		}
+		switch str[i+1] {
+		// " \ /
+		case 34, 92, 47:
+			buf = append(buf, str[i+1])
+		// b
+		case 98:
+			buf = append(buf, 8)
+		// f
+		case 102:
+			buf = append(buf, 12)
+		// n
+		case 110:
+			buf = append(buf, 10)
+		// r
+		case 114:
+			buf = append(buf, 13)
+		// t
+		case 116:
+			buf = append(buf, 9)
+		// u
+		case 117:
+			r, size := unescapeUnicode(str[i:])
+			if size == 0 {
+				buf = append(buf, curr)
+				i++
+				continue
+			}
+			buf = utf8.AppendRune(buf, r)
+			i += size
+			continue
+		default:
+			buf = append(buf, curr, str[i+1])
+		}
+		i += 2
+	}
+	return buf
+}
+
+// unescapeUnicode decodes a '\uXXXX' sequence at the start of str,
+// surrogate pairs like '\ud83d\ude00' decoded as a single rune.
+// size is zero if str does not start with a valid sequence.
+func unescapeUnicode(str []byte) (rune, int) {
+	r := hexRune(str)
+	if r == -1 {
+		return 0, 0
+	}
+	if !utf16.IsSurrogate(r) {
+		return r, 6
+	}
+	if pair := utf16.DecodeRune(r, hexRune(str[6:])); pair != utf8.RuneError {
+		return pair, 12
+	}
+	return utf8.RuneError, 6
+}
+
+// hexRune returns the value of a '\uXXXX' sequence at the start of str, -1 if there is not.
+func hexRune(str []byte) rune {
+	// 92 = \, 117 = u
+	if len(str) < 6 || str[0] != 92 || str[1] != 117 {
+		return -1
+	}
+	r, err := strconv.ParseUint(string(str[2:6]), 16, 16)
+	if err != nil {
+		return -1
+	}
+	return rune(r)
+}
+
 func stringToByteArray(str string) []byte {
 	return *(*[]byte)(unsafe.Pointer(&str))
 }
@@ -200,7 +400,7 @@ func byteArrayToString(arr []byte) string {
 func getStartEnd(json []byte, path ...string) (int, int, error) {
 	lenj := len(json)
 	if lenj < 2 {
-		return -1, -1, badJSONError(0)
+		return -1, -1, badJSONError(json, lenj, "value")
 	}
 	var err error
 	var start int
@@ -214,7 +414,7 @@ func getStartEnd(json []byte, path ...string) (int, int, error) {
 	} else {
 		for space(json[start]) {
 			if start > len(json)-1 {
-				return -1, -1, badJSONError(start)
+				return -1, -1, badJSONError(json, start, "value")
 			}
 			start++
 			continue
@@ -222,7 +422,7 @@ func getStartEnd(json []byte, path ...string) (int, int, error) {
 		end = lenj - 1
 		for space(json[end]) {
 			if end < start {
-				return -1, -1, badJSONError(start)
+				return -1, -1, badJSONError(json, start, "value")
 			}
 			end--
 			continue
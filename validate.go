@@ -0,0 +1,235 @@
+package jin
+
+import "unicode/utf8"
+
+// Valid reports whether json is a valid JSON document according to RFC 8259.
+// Other functions of this package are not strict, they skip anything that is not important for them.
+// Valid reads the json only once and does not allocate for documents nested less than 32 levels,
+// so it is cheap enough to run before Parse() or any other function.
+func Valid(json []byte) bool {
+	return ValidateDetailed(json) == nil
+}
+
+// ValidateDetailed is a strict RFC 8259 validator, it returns nil if json is valid.
+// Otherwise it returns an *Error with code ErrBadJSON,
+// error has the offset, line, column, expected token and an excerpt of the first problem.
+// It checks number grammar, literals, string escapes, control characters,
+// UTF-8 encoding and trailing data after the document.
+func ValidateDetailed(json []byte) error {
+	// stack of open braces, it grows with nested objects and arrays.
+	var buffer [32]byte
+	stack := buffer[:0]
+	i := skipSpace(json, 0)
+	for {
+		// a value expected at json[i].
+		if i >= len(json) {
+			return badJSONError(json, i, "value")
+		}
+		var err error
+		switch curr := json[i]; {
+		// 123 = {
+		case curr == 123:
+			i = skipSpace(json, i+1)
+			// 125 = }
+			if i < len(json) && json[i] == 125 {
+				i++
+				break
+			}
+			stack = append(stack, curr)
+			i, err = validateKey(json, i)
+			if err != nil {
+				return err
+			}
+			continue
+		// 91 = [
+		case curr == 91:
+			i = skipSpace(json, i+1)
+			// 93 = ]
+			if i < len(json) && json[i] == 93 {
+				i++
+				break
+			}
+			stack = append(stack, curr)
+			continue
+		// 34 = "
+		case curr == 34:
+			i, err = validateString(json, i)
+		// 45 = -, 48-57 = digits
+		case curr == 45 || (curr >= 48 && curr <= 57):
+			i, err = validateNumber(json, i)
+		// 116 = t
+		case curr == 116:
+			i, err = validateLiteral(json, i, "true")
+		// 102 = f
+		case curr == 102:
+			i, err = validateLiteral(json, i, "false")
+		// 110 = n
+		case curr == 110:
+			i, err = validateLiteral(json, i, "null")
+		default:
+			return badJSONError(json, i, "value")
+		}
+		if err != nil {
+			return err
+		}
+		// a value completed, closing braces or a comma expected.
+		for {
+			i = skipSpace(json, i)
+			if len(stack) == 0 {
+				if i != len(json) {
+					return badJSONError(json, i, "end of input")
+				}
+				return nil
+			}
+			brace := stack[len(stack)-1]
+			if i >= len(json) {
+				return badJSONError(json, i, "',' or '"+string(brace+2)+"'")
+			}
+			// 44 = ,
+			if json[i] == 44 {
+				i = skipSpace(json, i+1)
+				if brace == 123 {
+					i, err = validateKey(json, i)
+					if err != nil {
+						return err
+					}
+				}
+				break
+			}
+			// 93 = ], 125 = }
+			if json[i] == brace+2 {
+				stack = stack[:len(stack)-1]
+				i++
+				continue
+			}
+			return badJSONError(json, i, "',' or '"+string(brace+2)+"'")
+		}
+	}
+}
+
+// validateKey validates a key and colon that starts at json[i],
+// returns the offset of the value.
+func validateKey(json []byte, i int) (int, error) {
+	// 34 = "
+	if i >= len(json) || json[i] != 34 {
+		return -1, badJSONError(json, i, "'\"'")
+	}
+	i, err := validateString(json, i)
+	if err != nil {
+		return -1, err
+	}
+	i = skipSpace(json, i)
+	// 58 = :
+	if i >= len(json) || json[i] != 58 {
+		return -1, badJSONError(json, i, "':'")
+	}
+	return skipSpace(json, i+1), nil
+}
+
+// validateString validates a string that starts at json[i],
+// returns the offset after closing quotation mark.
+func validateString(json []byte, i int) (int, error) {
+	for i++; i < len(json); {
+		curr := json[i]
+		switch {
+		// 34 = "
+		case curr == 34:
+			return i + 1, nil
+		// 92 = \
+		case curr == 92:
+			if i+1 >= len(json) {
+				return -1, badJSONError(json, i+1, "escape character")
+			}
+			switch json[i+1] {
+			// " \ / b f n r t
+			case 34, 92, 47, 98, 102, 110, 114, 116:
+				i += 2
+			// 117 = u
+			case 117:
+				for j := i + 2; j < i+6; j++ {
+					if j >= len(json) || !isHex(json[j]) {
+						return -1, badJSONError(json, j, "hex digit")
+					}
+				}
+				i += 6
+			default:
+				return -1, badJSONError(json, i+1, "escape character")
+			}
+		// control characters must be escaped.
+		case curr < 32:
+			return -1, badJSONError(json, i, "escaped control character")
+		case curr < 128:
+			i++
+		default:
+			r, size := utf8.DecodeRune(json[i:])
+			if r == utf8.RuneError && size == 1 {
+				return -1, badJSONError(json, i, "valid UTF-8")
+			}
+			i += size
+		}
+	}
+	return -1, badJSONError(json, i, "'\"'")
+}
+
+// validateNumber validates a number that starts at json[i],
+// returns the offset after the last digit.
+func validateNumber(json []byte, i int) (int, error) {
+	// 45 = -
+	if json[i] == 45 {
+		i++
+	}
+	// 48 = 0
+	if i < len(json) && json[i] == 48 {
+		i++
+	} else {
+		if i >= len(json) || !isDigit(json[i]) {
+			return -1, badJSONError(json, i, "digit")
+		}
+		for i < len(json) && isDigit(json[i]) {
+			i++
+		}
+	}
+	// 46 = .
+	if i < len(json) && json[i] == 46 {
+		i++
+		if i >= len(json) || !isDigit(json[i]) {
+			return -1, badJSONError(json, i, "digit")
+		}
+		for i < len(json) && isDigit(json[i]) {
+			i++
+		}
+	}
+	// 101 = e, 69 = E
+	if i < len(json) && (json[i] == 101 || json[i] == 69) {
+		i++
+		// 43 = +, 45 = -
+		if i < len(json) && (json[i] == 43 || json[i] == 45) {
+			i++
+		}
+		if i >= len(json) || !isDigit(json[i]) {
+			return -1, badJSONError(json, i, "digit")
+		}
+		for i < len(json) && isDigit(json[i]) {
+			i++
+		}
+	}
+	return i, nil
+}
+
+// validateLiteral validates true, false or null literal at json[i].
+func validateLiteral(json []byte, i int, literal string) (int, error) {
+	for j := 0; j < len(literal); j++ {
+		if i+j >= len(json) || json[i+j] != literal[j] {
+			return -1, badJSONError(json, i+j, "'"+literal+"'")
+		}
+	}
+	return i + len(literal), nil
+}
+
+func isDigit(curr byte) bool {
+	return curr >= 48 && curr <= 57
+}
+
+func isHex(curr byte) bool {
+	return isDigit(curr) || (curr >= 65 && curr <= 70) || (curr >= 97 && curr <= 102)
+}
@@ -0,0 +1,113 @@
+package jin
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateDetailed(t *testing.T) {
+	tests := []struct {
+		name     string
+		json     string
+		offset   int
+		expected string
+	}{
+		// valid documents, offset is -1.
+		{"object", `{"a":[1,2,{"b":null}],"c":true}`, -1, ""},
+		{"scalar", ` 42 `, -1, ""},
+		{"empty containers", `[{},[],""]`, -1, ""},
+		{"zero", `0`, -1, ""},
+		{"negative zero", `-0`, -1, ""},
+		{"fraction", `-0.5`, -1, ""},
+		{"exponent", `1e10`, -1, ""},
+		{"signed exponent", `1.5E-3`, -1, ""},
+		{"plus exponent", `2e+8`, -1, ""},
+		{"escapes", `"\" \\ \/ \b \f \n \r \t"`, -1, ""},
+		{"unicode escape", `"\u00e9\u00E9"`, -1, ""},
+		{"escaped surrogate pair", `"\ud83d\ude00"`, -1, ""},
+		// RFC 8259 grammar allows any \uXXXX, lone surrogates are not rejected.
+		{"escaped lone surrogate", `"\ud800"`, -1, ""},
+		{"utf-8", `"café 😀"`, -1, ""},
+		{"replacement character", "\"\xef\xbf\xbd\"", -1, ""},
+		{"spaces", " \t\r\n{ \"a\" : 1 } \n", -1, ""},
+
+		// number grammar
+		{"leading zero", `01`, 1, "end of input"},
+		{"leading zero in array", `[01]`, 2, "',' or ']'"},
+		{"minus only", `-`, 1, "digit"},
+		{"minus letter", `-a`, 1, "digit"},
+		{"plus sign", `+1`, 0, "value"},
+		{"trailing dot", `1.`, 2, "digit"},
+		{"leading dot", `.5`, 0, "value"},
+		{"empty exponent", `1e`, 2, "digit"},
+		{"signed empty exponent", `1e-`, 3, "digit"},
+		{"dot exponent", `1.e5`, 2, "digit"},
+		{"hex", `0x10`, 1, "end of input"},
+		{"infinity", `Infinity`, 0, "value"},
+		{"nan", `[NaN]`, 1, "value"},
+
+		// escapes
+		{"bad escape", `"\a"`, 2, "escape character"},
+		{"short unicode", `"\u12"`, 5, "hex digit"},
+		{"bad hex", `"\u12g4"`, 5, "hex digit"},
+		{"escape at end", `"\`, 2, "escape character"},
+		{"single quote escape", `"\'"`, 2, "escape character"},
+
+		// control characters
+		{"newline in string", "\"a\nb\"", 2, "escaped control character"},
+		{"tab in string", "\"a\tb\"", 2, "escaped control character"},
+		{"nul in string", "\"\x00\"", 1, "escaped control character"},
+
+		// invalid UTF-8 and surrogates
+		{"invalid byte", "\"a\xffb\"", 2, "valid UTF-8"},
+		{"truncated sequence", "\"\xc3\"", 1, "valid UTF-8"},
+		{"overlong encoding", "\"\xc0\x80\"", 1, "valid UTF-8"},
+		{"encoded surrogate", "\"\xed\xa0\x80\"", 1, "valid UTF-8"},
+		{"continuation byte", "[\"\x80\"]", 2, "valid UTF-8"},
+
+		// structure and trailing data
+		{"empty", ``, 0, "value"},
+		{"spaces only", `   `, 3, "value"},
+		{"trailing comma", `[1,]`, 3, "value"},
+		{"trailing comma in object", `{"a":1,}`, 7, "'\"'"},
+		{"missing colon", `{"a" 1}`, 5, "':'"},
+		{"single quotes", `{'a':1}`, 1, "'\"'"},
+		{"unclosed array", `[1,2`, 4, "',' or ']'"},
+		{"unclosed object", `{"a":1`, 6, "',' or '}'"},
+		{"unclosed string", `"abc`, 4, "'\"'"},
+		{"wrong close", `[1}`, 2, "',' or ']'"},
+		{"trailing data", `{} x`, 3, "end of input"},
+		{"two documents", `1 2`, 2, "end of input"},
+		{"bad literal", `tru`, 3, "'true'"},
+		{"capital literal", `True`, 0, "value"},
+		{"deep unclosed", `[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[`, 36, "value"},
+	}
+	for _, test := range tests {
+		err := ValidateDetailed([]byte(test.json))
+		if Valid([]byte(test.json)) != (err == nil) {
+			t.Errorf("%v: Valid() and ValidateDetailed() do not agree", test.name)
+		}
+		if test.offset == -1 {
+			if err != nil {
+				t.Errorf("%v: unexpected error %v", test.name, err)
+			}
+			continue
+		}
+		var e *Error
+		if !errors.As(err, &e) || !errors.Is(err, ErrBadJSON) {
+			t.Errorf("%v: got %v, want a bad json error", test.name, err)
+			continue
+		}
+		if e.Offset != test.offset || e.Expected != test.expected {
+			t.Errorf("%v: got offset %v expected %q, want offset %v expected %q", test.name, e.Offset, e.Expected, test.offset, test.expected)
+		}
+	}
+}
+
+func TestValidateDetailedLineColumn(t *testing.T) {
+	err := ValidateDetailed([]byte("{\n  \"a\": 1,\n  \"b\": tru\n}"))
+	var e *Error
+	if !errors.As(err, &e) || e.Line != 3 || e.Column != 11 || e.Offset != 22 {
+		t.Errorf("got %v, want line 3 column 11 offset 22", err)
+	}
+}